@@ -3,10 +3,12 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"os"
 	"os/signal"
 	"song_lib/internal/app"
 	"song_lib/internal/config"
+	"song_lib/internal/logger"
 	"syscall"
 
 	"github.com/sirupsen/logrus"
@@ -14,27 +16,59 @@ import (
 
 func main() {
 	path := ""
+	checkConfig := false
 	flag.StringVar(&path, "c", "", "path to the configuration file")
+	flag.BoolVar(&checkConfig, "check-config", false, "validate the configuration and exit")
 	flag.Parse()
 
-	ctx := context.Background()
 	log := logrus.New()
-	cfg, err := config.LoadConfig()
+	cfg, err := config.LoadConfig(path)
+	if checkConfig {
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println("configuration is valid")
+		return
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	app := app.NewApp(ctx, cfg, log)
+	logger.Configure(log, &cfg.Log)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
 
+	app, err := app.NewApp(ctx, cfg, log)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	errCh := make(chan error, 1)
 	go func() {
-		app.Start()
+		errCh <- app.Start()
 	}()
 	log.Info("server is running")
 
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
+	exitCode := 0
+	select {
+	case <-ctx.Done():
+		log.Info("shutdown signal received")
+	case err := <-errCh:
+		if err != nil {
+			log.Error(err)
+			exitCode = 1
+		}
+	}
+	cancel()
 
-	<-stop
-	app.Stop(ctx)
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	if err := app.Stop(stopCtx); err != nil {
+		log.Error(err)
+		exitCode = 1
+	}
+	stopCancel()
 	log.Info("server shutdown")
+
+	os.Exit(exitCode)
 }
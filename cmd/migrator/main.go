@@ -16,7 +16,7 @@ func main() {
 	var up, down bool
 
 	flag.StringVar(&migrationPath, "m", "", "path to the migration dir")
-	flag.StringVar(&configPath, "c", "", "path to the config dir")
+	flag.StringVar(&configPath, "c", "", "path to the configuration file")
 	flag.BoolVar(&up, "up", false, "apply migrations")
 	flag.BoolVar(&down, "down", false, "rollback migrations")
 	flag.Parse()
@@ -27,15 +27,12 @@ func main() {
 		log.Fatal("You must specify either --up or --down")
 	}
 
-	cfg, err := config.LoadConfig()
+	cfg, err := config.LoadConfig(configPath)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	dbUrl := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		cfg.DB.Username, cfg.DB.Password, cfg.DB.Host, cfg.DB.Port, cfg.DB.DBName, cfg.DB.SSLMode)
-
-	m, err := migrate.New("file://"+migrationPath, dbUrl)
+	m, err := migrate.New("file://"+migrationPath, cfg.DB.ConnString())
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -2,52 +2,220 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"song_lib/internal/app/server"
 	"song_lib/internal/config"
+	domainusecase "song_lib/internal/domain/usecase"
 	"song_lib/internal/group"
 	"song_lib/internal/handler"
+	"song_lib/internal/metrics"
+	"song_lib/internal/middleware"
 	"song_lib/internal/repository"
+	"song_lib/internal/tracing"
 	"song_lib/internal/usecase"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sirupsen/logrus"
-	ginlogrus "github.com/toorop/gin-logrus"
 )
 
+// worker is a background component that must be stopped after the HTTP
+// server and before the database pools.
+type worker func(ctx context.Context) error
+
 type App struct {
-	server *server.Server
-	pool   *pgxpool.Pool
+	server     *server.Server
+	admin      *server.Server
+	pools      []*pgxpool.Pool
+	workers    []worker
+	health     domainusecase.Health
+	drainDelay time.Duration
+	log        *logrus.Logger
 }
 
-func NewApp(ctx context.Context, cfg *config.Config, log *logrus.Logger) *App {
-	dsn := fmt.Sprintf("postgresql://%s:%s@%s:%s/%s", cfg.DB.Username, cfg.DB.Password, cfg.DB.Host, cfg.DB.Port, cfg.DB.DBName)
+func NewApp(ctx context.Context, cfg *config.Config, log *logrus.Logger) (_ *App, err error) {
+	tracer, err := tracing.NewTracer(&cfg.Tracing, log)
+	if err != nil {
+		return nil, fmt.Errorf("create tracer: %w", err)
+	}
+
+	var pools []*pgxpool.Pool
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		closePools(pools)
+		// ctx may already be canceled by the signal that aborted startup.
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+		defer cancel()
+		if err := tracer.Shutdown(shutdownCtx); err != nil {
+			log.WithField("op", "internal/app/app/NewApp").WithError(err).Error("Failed to shut down tracer")
+		}
+	}()
+
+	pool, err := connectDB(ctx, cfg.DB.ConnString(), &cfg.DB, tracer, log)
+	if err != nil {
+		return nil, err
+	}
+	pools = append(pools, pool)
+
+	var replica *pgxpool.Pool
+	if cfg.DB.ReplicaHost != "" {
+		replica, err = connectDB(ctx, cfg.DB.ReplicaConnString(), &cfg.DB, tracer, log)
+		if err != nil {
+			return nil, fmt.Errorf("read replica: %w", err)
+		}
+		pools = append(pools, replica)
+	}
+
+	m := metrics.NewMetrics()
+	m.RegisterPool(pool, "primary")
+	if replica != nil {
+		m.RegisterPool(replica, "replica")
+	}
 
-	pool, err := pgxpool.New(ctx, dsn)
+	repos := repository.NewRepositories(pool, replica, log, tracer)
+	usecases := usecase.NewUsecases(cfg, repos, log, m, tracer)
+	groups := group.NewGroups(cfg, usecases, log)
+	middlewares, err := middleware.NewMiddlewares(cfg, log, m, tracer, usecases)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("create middlewares: %w", err)
 	}
 
-	repos := repository.NewRepositories(pool, log)
-	usecases := usecase.NewUsecases(repos, log)
-	groups := group.NewGroups(usecases, log)
 	router := gin.New()
-	router.Use(ginlogrus.Logger(log))
-	handler.InitRoutes(router, *groups)
-	server := server.NewServer(&cfg.Server, router)
+	router.ContextWithFallback = true
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("set trusted proxies: %w", err)
+	}
+	router.Use(
+		middleware.RequestID(),
+		middlewares.Tracing.Trace(),
+		middleware.AccessLog(log),
+		middlewares.Metrics.Observe(),
+	)
+	handler.InitRoutes(router, *groups, *middlewares)
+
+	adminRouter := gin.New()
+	adminRouter.ContextWithFallback = true
+	// The admin listener is never meant to sit behind a proxy.
+	_ = adminRouter.SetTrustedProxies(nil)
+	adminRouter.Use(middleware.RequestID(), middleware.AccessLog(log))
+	handler.InitAdminRoutes(adminRouter, *groups, *middlewares, m.Handler(), cfg.Admin.Pprof)
+	admin, err := server.NewServer(adminServerConfig(cfg), adminRouter, log)
+	if err != nil {
+		return nil, fmt.Errorf("create admin server: %w", err)
+	}
+
+	server, err := server.NewServer(&cfg.Server, router, log)
+	if err != nil {
+		return nil, fmt.Errorf("create http server: %w", err)
+	}
 
 	return &App{
 		server: server,
-		pool:   pool,
+		admin:  admin,
+		pools:  pools,
+		workers: []worker{
+			usecases.Idempotency.RunCleanup(cfg.Idempotency.CleanupInterval),
+			watchMaintenanceSignal(usecases.Maintenance, log),
+			tracer.Shutdown,
+		},
+		health:     usecases.Health,
+		drainDelay: cfg.Server.DrainDelay,
+		log:        log,
+	}, nil
+}
+
+// adminServerConfig runs the admin listener over plain HTTP with the public
+// server's limits, except for a longer write timeout that fits pprof.
+func adminServerConfig(cfg *config.Config) *config.Server {
+	return &config.Server{
+		Host:              cfg.Admin.Host,
+		Port:              cfg.Admin.Port,
+		ReadTime:          cfg.Server.ReadTime,
+		WriteTime:         cfg.Admin.WriteTime,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+	}
+}
+
+// Start serves the public and admin listeners until they are stopped. It
+// returns nil after a graceful Stop and the first listener error otherwise.
+func (a *App) Start() error {
+	errCh := make(chan error, 2)
+	go func() {
+		errCh <- serve("http server", a.server)
+	}()
+	go func() {
+		errCh <- serve("admin server", a.admin)
+	}()
+
+	for range 2 {
+		if err := <-errCh; err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
-func (a *App) Start() {
-	a.server.Run()
+func serve(name string, s *server.Server) error {
+	if err := s.Run(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	return nil
 }
 
-func (a *App) Stop(ctx context.Context) {
-	a.server.Stop(ctx)
-	a.pool.Close()
+// Stop drains and shuts down the HTTP server, then the admin server, then
+// background workers, then the database pools. Every step shares the
+// deadline of ctx.
+func (a *App) Stop(ctx context.Context) error {
+	log := a.log.WithField("op", "internal/app/app/Stop")
+
+	a.health.Drain()
+	select {
+	case <-time.After(a.drainDelay):
+	case <-ctx.Done():
+	}
+
+	var errs []error
+
+	if err := a.server.Stop(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("http server: %w", err))
+	}
+	log.Info("HTTP server stopped")
+
+	// The admin listener goes last so probes and metrics stay available
+	// while the public server drains.
+	if err := a.admin.Stop(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("admin server: %w", err))
+	}
+	log.Info("Admin server stopped")
+
+	for _, stop := range a.workers {
+		if err := stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("background worker: %w", err))
+		}
+	}
+	log.Info("Background workers stopped")
+
+	closed := make(chan struct{})
+	go func() {
+		closePools(a.pools)
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		log.Info("Database pools closed")
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("database pools: %w", ctx.Err()))
+	}
+
+	return errors.Join(errs...)
 }
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"song_lib/internal/config"
+	"song_lib/internal/tracing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+// connectDB opens a pool to connString with the pool settings from cfg and
+// pings it, retrying with exponential backoff so the API can start before
+// Postgres is accepting connections.
+func connectDB(ctx context.Context, connString string, cfg *config.DB, tracer *tracing.Tracer, log *logrus.Logger) (*pgxpool.Pool, error) {
+	l := log.WithField("op", "internal/app/db/connectDB")
+
+	poolCfg, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, fmt.Errorf("parse database config: %w", err)
+	}
+	poolCfg.ConnConfig.Tracer = tracing.NewQueryTracer(tracer)
+	poolCfg.MaxConns = cfg.MaxConns
+	poolCfg.MinConns = cfg.MinConns
+	poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	poolCfg.MaxConnIdleTime = cfg.MaxConnIdleTime
+	poolCfg.HealthCheckPeriod = cfg.HealthCheckPeriod
+
+	backoff := cfg.ConnectBackoff
+	for attempt := 1; ; attempt++ {
+		pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+		if err == nil {
+			err = pool.Ping(ctx)
+			if err == nil {
+				return pool, nil
+			}
+			pool.Close()
+		}
+
+		if attempt >= cfg.ConnectAttempts {
+			return nil, fmt.Errorf("connect to database after %d attempts: %w", attempt, err)
+		}
+
+		l.WithError(err).Warnf("Database is not available (attempt %d/%d), retrying in %s", attempt, cfg.ConnectAttempts, backoff)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("connect to database: %w", ctx.Err())
+		}
+
+		backoff = min(backoff*2, cfg.ConnectMaxBackoff)
+	}
+}
+
+func closePools(pools []*pgxpool.Pool) {
+	for _, pool := range pools {
+		pool.Close()
+	}
+}
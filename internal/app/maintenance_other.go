@@ -0,0 +1,16 @@
+//go:build !unix
+
+package app
+
+import (
+	"context"
+	"song_lib/internal/domain/usecase"
+
+	"github.com/sirupsen/logrus"
+)
+
+// watchMaintenanceSignal is a no-op where SIGUSR1 does not exist; use the
+// admin endpoint instead.
+func watchMaintenanceSignal(usecase.Maintenance, *logrus.Logger) worker {
+	return func(context.Context) error { return nil }
+}
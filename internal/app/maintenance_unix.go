@@ -0,0 +1,48 @@
+//go:build unix
+
+package app
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"song_lib/internal/domain/usecase"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+)
+
+// watchMaintenanceSignal toggles read-only maintenance mode on SIGUSR1.
+func watchMaintenanceSignal(maintenance usecase.Maintenance, log *logrus.Logger) worker {
+	l := log.WithField("op", "internal/app/maintenance/watchMaintenanceSignal")
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGUSR1)
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-sig:
+				status := maintenance.Toggle()
+				l.Infof("SIGUSR1 received, read-only mode is now %t", status.ReadOnly)
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	return func(ctx context.Context) error {
+		signal.Stop(sig)
+		close(stop)
+
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
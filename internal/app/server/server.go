@@ -2,29 +2,59 @@ package server
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"song_lib/internal/config"
+
+	"github.com/sirupsen/logrus"
 )
 
 type Server struct {
 	httpServer *http.Server
+	certs      *certReloader
 }
 
-func NewServer(cfg *config.Server, handler http.Handler) *Server {
-	return &Server{
+func NewServer(cfg *config.Server, handler http.Handler, log *logrus.Logger) (*Server, error) {
+	s := &Server{
 		httpServer: &http.Server{
-			Addr:         ":" + cfg.Port,
-			Handler:      handler,
-			ReadTimeout:  cfg.ReadTime,
-			WriteTimeout: cfg.WriteTime,
+			Addr:              net.JoinHostPort(cfg.Host, cfg.Port),
+			Handler:           handler,
+			ReadTimeout:       cfg.ReadTime,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			WriteTimeout:      cfg.WriteTime,
+			IdleTimeout:       cfg.IdleTimeout,
+			MaxHeaderBytes:    cfg.MaxHeaderBytes,
 		},
 	}
+
+	if cfg.TLSCertFile != "" {
+		certs, err := newCertReloader(cfg, log)
+		if err != nil {
+			return nil, err
+		}
+		s.certs = certs
+		s.httpServer.TLSConfig = certs.tlsConfig()
+		go certs.watch()
+	}
+
+	return s, nil
 }
 
+// Run serves plain HTTP, or HTTPS with HTTP/2 when a certificate is
+// configured.
 func (s *Server) Run() error {
-	return s.httpServer.ListenAndServe()
+	if s.certs == nil {
+		return s.httpServer.ListenAndServe()
+	}
+
+	return s.httpServer.ListenAndServeTLS("", "")
 }
 
 func (s *Server) Stop(ctx context.Context) error {
-	return s.httpServer.Shutdown(ctx)
+	err := s.httpServer.Shutdown(ctx)
+	if s.certs != nil {
+		s.certs.close()
+	}
+
+	return err
 }
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"song_lib/internal/config"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+var clientAuthModes = map[string]tls.ClientAuthType{
+	"require":         tls.RequireAndVerifyClientCert,
+	"verify_if_given": tls.VerifyClientCertIfGiven,
+}
+
+// certReloader serves the current certificate and client CA pool and swaps
+// them when the files change or the process gets SIGHUP. Handshakes in
+// flight and established connections keep the config they started with.
+type certReloader struct {
+	certFile   string
+	keyFile    string
+	clientCA   string
+	minVersion uint16
+	clientAuth tls.ClientAuthType
+	interval   time.Duration
+	log        *logrus.Logger
+
+	current atomic.Pointer[tls.Config]
+	modTime time.Time
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func newCertReloader(cfg *config.Server, log *logrus.Logger) (*certReloader, error) {
+	r := &certReloader{
+		certFile:   cfg.TLSCertFile,
+		keyFile:    cfg.TLSKeyFile,
+		clientCA:   cfg.TLSClientCA,
+		minVersion: tlsVersions[cfg.TLSMinVersion],
+		clientAuth: clientAuthModes[cfg.TLSClientAuth],
+		interval:   cfg.TLSReloadInterval,
+		log:        log,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// tlsConfig is what the http.Server is given. Every handshake is answered
+// with the most recently loaded config.
+func (r *certReloader) tlsConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: r.minVersion,
+		NextProtos: []string{"h2", "http/1.1"},
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return &r.current.Load().Certificates[0], nil
+		},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.current.Load(), nil
+		},
+	}
+}
+
+func (r *certReloader) load() error {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load tls key pair: %w", err)
+	}
+
+	cfg := &tls.Config{
+		MinVersion:   r.minVersion,
+		NextProtos:   []string{"h2", "http/1.1"},
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if r.clientCA != "" {
+		pem, err := os.ReadFile(r.clientCA)
+		if err != nil {
+			return fmt.Errorf("read tls client ca: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.New("tls client ca contains no certificates")
+		}
+
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = r.clientAuth
+	}
+
+	r.current.Store(cfg)
+	r.modTime = modTime
+
+	return nil
+}
+
+func (r *certReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, path := range []string{r.certFile, r.keyFile, r.clientCA} {
+		if path == "" {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("stat tls file: %w", err)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+
+	return latest, nil
+}
+
+// watch reloads on SIGHUP and whenever one of the files has a newer
+// modification time. A failed reload keeps the previous certificate.
+func (r *certReloader) watch() {
+	defer close(r.done)
+
+	log := r.log.WithField("op", "internal/app/server/tls/watch")
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-hup:
+			log.Info("Reloading TLS certificates on SIGHUP")
+		case <-ticker.C:
+			modTime, err := r.latestModTime()
+			if err != nil {
+				log.WithError(err).Warn("Failed to check TLS certificates")
+				continue
+			}
+			if !modTime.After(r.modTime) {
+				continue
+			}
+			log.Info("TLS certificate files changed, reloading")
+		}
+
+		if err := r.load(); err != nil {
+			log.WithError(err).Error("Failed to reload TLS certificates, keeping the previous ones")
+			continue
+		}
+		log.Info("TLS certificates reloaded")
+	}
+}
+
+func (r *certReloader) close() {
+	close(r.stop)
+	<-r.done
+}
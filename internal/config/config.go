@@ -11,6 +11,17 @@ import (
 type Config struct {
 	DB
 	Server
+	Auth
+	RateLimit
+	Tracing
+	Log
+	Idempotency
+	Cache
+	Compression
+	CORS
+	Admin
+	Maintenance
+	Bulk
 }
 
 type DB struct {
@@ -20,25 +31,219 @@ type DB struct {
 	DBName   string `env:"DB_NAME" env-required:"true"`
 	SSLMode  string `env:"SSL_MODE" env-required:"true"`
 	Password string `env:"DB_PASSWORD" env-required:"true"`
+
+	SSLRootCert     string        `env:"DB_SSL_ROOT_CERT"`
+	SSLCert         string        `env:"DB_SSL_CERT"`
+	SSLKey          string        `env:"DB_SSL_KEY"`
+	ConnectTimeout  time.Duration `env:"DB_CONNECT_TIMEOUT" envDefault:"10s"`
+	ApplicationName string        `env:"DB_APPLICATION_NAME" envDefault:"song_lib"`
+
+	ConnectAttempts   int           `env:"DB_CONNECT_ATTEMPTS" envDefault:"5"`
+	ConnectBackoff    time.Duration `env:"DB_CONNECT_BACKOFF" envDefault:"1s"`
+	ConnectMaxBackoff time.Duration `env:"DB_CONNECT_MAX_BACKOFF" envDefault:"30s"`
+
+	MaxConns          int32         `env:"DB_MAX_CONNS" envDefault:"10"`
+	MinConns          int32         `env:"DB_MIN_CONNS" envDefault:"0"`
+	MaxConnLifetime   time.Duration `env:"DB_MAX_CONN_LIFETIME" envDefault:"1h"`
+	MaxConnIdleTime   time.Duration `env:"DB_MAX_CONN_IDLE_TIME" envDefault:"30m"`
+	HealthCheckPeriod time.Duration `env:"DB_HEALTH_CHECK_PERIOD" envDefault:"1m"`
+
+	// ReplicaHost, when set, serves the read endpoints of songs, groups,
+	// albums, people, tags and relations. Writes and the change feed stay on
+	// the primary. It shares credentials and TLS settings with the primary.
+	ReplicaHost string `env:"DB_REPLICA_HOST"`
+	ReplicaPort string `env:"DB_REPLICA_PORT"` // defaults to DB_PORT
 }
 
 type Server struct {
+	Host      string        `env:"SERV_HOST"`
 	Port      string        `env:"SERV_PORT" env-required:"true"`
 	ReadTime  time.Duration `env:"READ_TIME" env-required:"true"`
 	WriteTime time.Duration `env:"WRITE_TIME" env-required:"true"`
+	// DrainDelay keeps serving after /readyz starts failing so load balancers
+	// can take the instance out of rotation before connections are closed.
+	DrainDelay      time.Duration `env:"DRAIN_DELAY" envDefault:"0s"`
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"15s"`
+
+	IdleTimeout       time.Duration `env:"IDLE_TIMEOUT" envDefault:"60s"`
+	ReadHeaderTimeout time.Duration `env:"READ_HEADER_TIMEOUT" envDefault:"5s"`
+	MaxHeaderBytes    int           `env:"MAX_HEADER_BYTES" envDefault:"1048576"`
+
+	// TrustedProxies lists the proxy IPs or CIDRs whose X-Forwarded-For is
+	// believed when resolving the client address. Empty trusts none.
+	TrustedProxies []string `env:"SERV_TRUSTED_PROXIES"`
+
+	// TLS is enabled when a certificate is set. Files are re-read when they
+	// change or on SIGHUP. A client CA turns on mutual TLS.
+	TLSCertFile       string        `env:"SERV_TLS_CERT"`
+	TLSKeyFile        string        `env:"SERV_TLS_KEY"`
+	TLSMinVersion     string        `env:"SERV_TLS_MIN_VERSION" envDefault:"1.2"` // 1.2 or 1.3
+	TLSClientCA       string        `env:"SERV_TLS_CLIENT_CA"`
+	TLSClientAuth     string        `env:"SERV_TLS_CLIENT_AUTH" envDefault:"require"` // require or verify_if_given
+	TLSReloadInterval time.Duration `env:"SERV_TLS_RELOAD_INTERVAL" envDefault:"30s"`
+}
+
+type Auth struct {
+	APIKeys     map[string]string `env:"AUTH_API_KEYS"` // key:role pairs, e.g. "k1:reader,k2:admin"
+	JWTSecret   string            `env:"AUTH_JWT_SECRET"`
+	JWTIssuer   string            `env:"AUTH_JWT_ISSUER"`
+	PublicReads bool              `env:"AUTH_PUBLIC_READS" envDefault:"true"`
+}
+
+type RateLimit struct {
+	Enabled    bool          `env:"RATE_LIMIT_ENABLED" envDefault:"true"`
+	ReadRPS    float64       `env:"RATE_LIMIT_READ_RPS" envDefault:"20"`
+	ReadBurst  int           `env:"RATE_LIMIT_READ_BURST" envDefault:"40"`
+	WriteRPS   float64       `env:"RATE_LIMIT_WRITE_RPS" envDefault:"2"`
+	WriteBurst int           `env:"RATE_LIMIT_WRITE_BURST" envDefault:"5"`
+	IdleTTL    time.Duration `env:"RATE_LIMIT_IDLE_TTL" envDefault:"10m"`
+}
+
+type Tracing struct {
+	Enabled       bool          `env:"TRACING_ENABLED" envDefault:"false"`
+	ServiceName   string        `env:"TRACING_SERVICE_NAME" envDefault:"song_lib"`
+	Exporter      string        `env:"TRACING_EXPORTER" envDefault:"file"` // file or http
+	FilePath      string        `env:"TRACING_FILE_PATH" envDefault:"traces.jsonl"`
+	Endpoint      string        `env:"TRACING_ENDPOINT"` // OTLP/HTTP JSON endpoint, e.g. http://collector:4318/v1/traces
+	SampleRatio   float64       `env:"TRACING_SAMPLE_RATIO" envDefault:"1"`
+	BatchSize     int           `env:"TRACING_BATCH_SIZE" envDefault:"512"`
+	FlushInterval time.Duration `env:"TRACING_FLUSH_INTERVAL" envDefault:"5s"`
+	ExportTimeout time.Duration `env:"TRACING_EXPORT_TIMEOUT" envDefault:"10s"`
+}
+
+type Log struct {
+	Level  string `env:"LOG_LEVEL" envDefault:"info"`
+	Format string `env:"LOG_FORMAT" envDefault:"text"` // text or json
+	// LyricsMaxLen is how many characters of lyrics may appear in logs; 0 hides them.
+	LyricsMaxLen int `env:"LOG_LYRICS_MAX_LEN" envDefault:"32"`
+}
+
+type Idempotency struct {
+	TTL             time.Duration `env:"IDEMPOTENCY_TTL" envDefault:"24h"`
+	CleanupInterval time.Duration `env:"IDEMPOTENCY_CLEANUP_INTERVAL" envDefault:"1h"`
+	// MaxBodySize caps the request body read to hash and replay a request.
+	MaxBodySize int64 `env:"IDEMPOTENCY_MAX_BODY_SIZE" envDefault:"1048576"`
+}
+
+// Cache holds the Cache-Control header sent on each cacheable read route.
+type Cache struct {
+	SongsInfo   string `env:"CACHE_CONTROL_SONGS_INFO" envDefault:"no-cache"`
+	Song        string `env:"CACHE_CONTROL_SONG" envDefault:"no-cache"`
+	SongsVerses string `env:"CACHE_CONTROL_SONGS_VERSES" envDefault:"no-cache"`
+}
+
+type Compression struct {
+	Enabled      bool     `env:"COMPRESSION_ENABLED" envDefault:"true"`
+	MinSize      int      `env:"COMPRESSION_MIN_SIZE" envDefault:"1024"` // bytes
+	Level        int      `env:"COMPRESSION_LEVEL" envDefault:"-1"`      // -1 is the library default, 1-9 trade speed for size
+	ContentTypes []string `env:"COMPRESSION_CONTENT_TYPES" envDefault:"application/json,text/plain,text/html,text/css,application/javascript"`
+	// MaxRequestSize caps a decompressed gzip request body.
+	MaxRequestSize int64 `env:"COMPRESSION_MAX_REQUEST_SIZE" envDefault:"10485760"`
 }
 
-func LoadConfig() (*Config, error) {
+// CORS is disabled while AllowedOrigins is empty. "*" allows any origin.
+type CORS struct {
+	AllowedOrigins   []string      `env:"CORS_ALLOWED_ORIGINS"`
+	AllowedMethods   []string      `env:"CORS_ALLOWED_METHODS" envDefault:"GET,POST,PUT,DELETE"`
+	AllowedHeaders   []string      `env:"CORS_ALLOWED_HEADERS" envDefault:"Authorization,Content-Type,Content-Encoding,X-API-Key,X-Request-ID,Idempotency-Key,If-None-Match,If-Modified-Since"`
+	ExposedHeaders   []string      `env:"CORS_EXPOSED_HEADERS" envDefault:"ETag,Last-Modified,Retry-After,X-Request-ID,X-RateLimit-Limit,X-RateLimit-Remaining,X-RateLimit-Reset,Idempotent-Replayed"`
+	AllowCredentials bool          `env:"CORS_ALLOW_CREDENTIALS" envDefault:"false"`
+	MaxAge           time.Duration `env:"CORS_MAX_AGE" envDefault:"10m"`
+}
+
+// Admin is the listener for swagger, metrics, health probes, pprof and log
+// level control. Log level and maintenance changes and pprof take an admin
+// API key or token; the rest is open, so it only listens on loopback unless
+// ADMIN_HOST says otherwise.
+type Admin struct {
+	Host      string        `env:"ADMIN_HOST" envDefault:"127.0.0.1"`
+	Port      string        `env:"ADMIN_PORT" envDefault:"8081"`
+	WriteTime time.Duration `env:"ADMIN_WRITE_TIME" envDefault:"60s"` // must exceed pprof profile durations
+	Pprof     bool          `env:"ADMIN_PPROF" envDefault:"false"`
+}
+
+// Maintenance makes the API read-only: writes get 503 with Retry-After. It can
+// also be switched at runtime from the admin listener or with SIGUSR1.
+type Maintenance struct {
+	ReadOnly   bool          `env:"MAINTENANCE_READ_ONLY" envDefault:"false"`
+	RetryAfter time.Duration `env:"MAINTENANCE_RETRY_AFTER" envDefault:"5m"`
+	Message    string        `env:"MAINTENANCE_MESSAGE" envDefault:"the library is read-only for maintenance, please retry later"`
+}
+
+type Bulk struct {
+	// MaxAffected caps how many songs a bulk operation may change unless the
+	// request is forced.
+	MaxAffected int `env:"BULK_MAX_AFFECTED" envDefault:"100"`
+}
+
+// LoadConfig reads the configuration from an optional YAML or JSON file at
+// path, overridden by environment variables (including .env). Secrets may be
+// supplied as NAME_FILE pointing to a file holding the value.
+func LoadConfig(path string) (*Config, error) {
 	godotenv.Load() //don't handle errors because we can upload via docker
 
+	environment, err := buildEnvironment(path)
+	if err != nil {
+		return nil, err
+	}
+	opts := env.Options{Environment: environment}
+
 	cfg := &Config{}
-	if err := env.Parse(&cfg.DB); err != nil {
+	if err := env.ParseWithOptions(&cfg.DB, opts); err != nil {
 		return nil, fmt.Errorf("configuration reading error DB: %w", err)
 	}
 
-	if err := env.Parse(&cfg.Server); err != nil {
+	if err := env.ParseWithOptions(&cfg.Server, opts); err != nil {
 		return nil, fmt.Errorf("configuration reading error Server: %w", err)
 	}
 
+	if err := env.ParseWithOptions(&cfg.Auth, opts); err != nil {
+		return nil, fmt.Errorf("configuration reading error Auth: %w", err)
+	}
+
+	if err := env.ParseWithOptions(&cfg.RateLimit, opts); err != nil {
+		return nil, fmt.Errorf("configuration reading error RateLimit: %w", err)
+	}
+
+	if err := env.ParseWithOptions(&cfg.Tracing, opts); err != nil {
+		return nil, fmt.Errorf("configuration reading error Tracing: %w", err)
+	}
+
+	if err := env.ParseWithOptions(&cfg.Log, opts); err != nil {
+		return nil, fmt.Errorf("configuration reading error Log: %w", err)
+	}
+
+	if err := env.ParseWithOptions(&cfg.Idempotency, opts); err != nil {
+		return nil, fmt.Errorf("configuration reading error Idempotency: %w", err)
+	}
+
+	if err := env.ParseWithOptions(&cfg.Cache, opts); err != nil {
+		return nil, fmt.Errorf("configuration reading error Cache: %w", err)
+	}
+
+	if err := env.ParseWithOptions(&cfg.Compression, opts); err != nil {
+		return nil, fmt.Errorf("configuration reading error Compression: %w", err)
+	}
+
+	if err := env.ParseWithOptions(&cfg.CORS, opts); err != nil {
+		return nil, fmt.Errorf("configuration reading error CORS: %w", err)
+	}
+
+	if err := env.ParseWithOptions(&cfg.Admin, opts); err != nil {
+		return nil, fmt.Errorf("configuration reading error Admin: %w", err)
+	}
+
+	if err := env.ParseWithOptions(&cfg.Maintenance, opts); err != nil {
+		return nil, fmt.Errorf("configuration reading error Maintenance: %w", err)
+	}
+
+	if err := env.ParseWithOptions(&cfg.Bulk, opts); err != nil {
+		return nil, fmt.Errorf("configuration reading error Bulk: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
@@ -0,0 +1,380 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFlatten(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  map[string]any
+		want map[string]string
+	}{
+		{
+			name: "top-level keys are upper-cased",
+			raw:  map[string]any{"serv_port": "8080", "LOG_LEVEL": "debug"},
+			want: map[string]string{"SERV_PORT": "8080", "LOG_LEVEL": "debug"},
+		},
+		{
+			name: "nested objects are joined with underscores",
+			raw:  map[string]any{"db": map[string]any{"host": "localhost", "max": map[string]any{"conns": float64(10)}}},
+			want: map[string]string{"DB_HOST": "localhost", "DB_MAX_CONNS": "10"},
+		},
+		{
+			name: "scalars",
+			raw:  map[string]any{"a": true, "b": 0.5, "c": nil, "d": 3},
+			want: map[string]string{"A": "true", "B": "0.5", "C": "", "D": "3"},
+		},
+		{
+			name: "lists become comma-separated",
+			raw:  map[string]any{"cors": map[string]any{"allowed_origins": []any{"https://a.example.com", "https://b.example.com"}}},
+			want: map[string]string{"CORS_ALLOWED_ORIGINS": "https://a.example.com,https://b.example.com"},
+		},
+		{
+			name: "map keys are kept as key:value pairs",
+			raw:  map[string]any{"auth": map[string]any{"api_keys": map[string]any{"secret": "admin"}}},
+			want: map[string]string{"AUTH_API_KEYS": "secret:admin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make(map[string]string)
+			flatten("", tt.raw, got)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("flatten() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:    "yaml",
+			file:    "config.yaml",
+			content: "db:\n  host: localhost\n  port: 5432\ncors:\n  allowed_methods: [GET, POST]\n",
+			want:    map[string]string{"DB_HOST": "localhost", "DB_PORT": "5432", "CORS_ALLOWED_METHODS": "GET,POST"},
+		},
+		{
+			name:    "json",
+			file:    "config.json",
+			content: `{"db": {"host": "localhost", "port": 5432}, "rate_limit": {"enabled": false}}`,
+			want:    map[string]string{"DB_HOST": "localhost", "DB_PORT": "5432", "RATE_LIMIT_ENABLED": "false"},
+		},
+		{
+			name:    "unsupported extension",
+			file:    "config.toml",
+			content: "db_host = 'localhost'",
+			wantErr: "unsupported config file extension",
+		},
+		{
+			name:    "malformed",
+			file:    "config.json",
+			content: "{",
+			wantErr: "parse config file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := readFile(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readFile() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readFile() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildEnvironmentSecrets(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		env      map[string]string
+		secret   string
+		want     string
+		wantErr  bool
+		noSecret bool
+	}{
+		{
+			name:   "file value",
+			file:   "db:\n  password: from-file\n",
+			want:   "from-file",
+			secret: "unused",
+		},
+		{
+			name:   "environment overrides file",
+			file:   "db:\n  password: from-file\n",
+			env:    map[string]string{"DB_PASSWORD": "from-env"},
+			want:   "from-env",
+			secret: "unused",
+		},
+		{
+			name:   "secret file with trailing newline",
+			env:    map[string]string{"DB_PASSWORD_FILE": "SECRET"},
+			secret: "from-secret\n",
+			want:   "from-secret",
+		},
+		{
+			name:   "secret file overrides config file",
+			file:   "db:\n  password: from-file\n",
+			env:    map[string]string{"DB_PASSWORD_FILE": "SECRET"},
+			secret: "from-secret",
+			want:   "from-secret",
+		},
+		{
+			name:   "environment overrides secret file",
+			env:    map[string]string{"DB_PASSWORD": "from-env", "DB_PASSWORD_FILE": "SECRET"},
+			secret: "from-secret",
+			want:   "from-env",
+		},
+		{
+			name:     "missing secret file",
+			env:      map[string]string{"DB_PASSWORD_FILE": "SECRET"},
+			noSecret: true,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			secretPath := filepath.Join(dir, "secret")
+			if !tt.noSecret {
+				if err := os.WriteFile(secretPath, []byte(tt.secret), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			t.Setenv("DB_PASSWORD", "")
+			os.Unsetenv("DB_PASSWORD")
+			t.Setenv("DB_PASSWORD_FILE", "")
+			os.Unsetenv("DB_PASSWORD_FILE")
+			for k, v := range tt.env {
+				if v == "SECRET" {
+					v = secretPath
+				}
+				t.Setenv(k, v)
+			}
+
+			var path string
+			if tt.file != "" {
+				path = filepath.Join(dir, "config.yaml")
+				if err := os.WriteFile(path, []byte(tt.file), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := buildEnvironment(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("buildEnvironment() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("buildEnvironment() error = %v", err)
+			}
+			if got["DB_PASSWORD"] != tt.want {
+				t.Errorf("DB_PASSWORD = %q, want %q", got["DB_PASSWORD"], tt.want)
+			}
+		})
+	}
+}
+
+func validConfig(t *testing.T) *Config {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "db:\n  username: song\n  host: localhost\n  port: 5432\n  name: song_lib\n  password: secret\nssl_mode: disable\nserv:\n  port: 8080\nread_time: 10s\nwrite_time: 10s\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	return cfg
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(c *Config)
+		want   []string
+	}{
+		{
+			name:   "defaults are valid",
+			mutate: func(*Config) {},
+		},
+		{
+			name: "missing required values",
+			mutate: func(c *Config) {
+				c.DB.Host = ""
+				c.Server.Port = ""
+			},
+			want: []string{"DB_HOST is required", "SERV_PORT is required"},
+		},
+		{
+			name:   "bad port",
+			mutate: func(c *Config) { c.DB.Port = "70000" },
+			want:   []string{"DB_PORT must be a port number"},
+		},
+		{
+			name:   "unknown ssl mode",
+			mutate: func(c *Config) { c.DB.SSLMode = "sometimes" },
+			want:   []string{"SSL_MODE must be one of"},
+		},
+		{
+			name:   "min conns above max conns",
+			mutate: func(c *Config) { c.DB.MinConns = c.DB.MaxConns + 1 },
+			want:   []string{"DB_MIN_CONNS must be between 0 and DB_MAX_CONNS"},
+		},
+		{
+			name:   "replica port without host",
+			mutate: func(c *Config) { c.DB.ReplicaPort = "5433" },
+			want:   []string{"DB_REPLICA_PORT requires DB_REPLICA_HOST"},
+		},
+		{
+			name:   "drain delay not shorter than shutdown timeout",
+			mutate: func(c *Config) { c.Server.DrainDelay = c.Server.ShutdownTimeout },
+			want:   []string{"DRAIN_DELAY must be between 0 and SHUTDOWN_TIMEOUT"},
+		},
+		{
+			name:   "tls key without cert",
+			mutate: func(c *Config) { c.Server.TLSKeyFile = "key.pem" },
+			want:   []string{"SERV_TLS_CERT and SERV_TLS_KEY must be set together"},
+		},
+		{
+			name: "admin port collides with server port",
+			mutate: func(c *Config) {
+				c.Admin.Host = c.Server.Host
+				c.Admin.Port = c.Server.Port
+			},
+			want: []string{"ADMIN_PORT must differ from SERV_PORT"},
+		},
+		{
+			name:   "short jwt secret",
+			mutate: func(c *Config) { c.Auth.JWTSecret = "short" },
+			want:   []string{"AUTH_JWT_SECRET must be at least 32 bytes"},
+		},
+		{
+			name:   "unknown api key role",
+			mutate: func(c *Config) { c.Auth.APIKeys = map[string]string{"key": "owner"} },
+			want:   []string{"AUTH_API_KEYS role must be one of"},
+		},
+		{
+			name: "rate limits only checked when enabled",
+			mutate: func(c *Config) {
+				c.RateLimit.Enabled = false
+				c.RateLimit.ReadRPS = 0
+			},
+		},
+		{
+			name: "rate limit without rps",
+			mutate: func(c *Config) {
+				c.RateLimit.Enabled = true
+				c.RateLimit.ReadRPS = 0
+			},
+			want: []string{"RATE_LIMIT_READ_RPS must be positive"},
+		},
+		{
+			name: "http tracing without endpoint",
+			mutate: func(c *Config) {
+				c.Tracing.Enabled = true
+				c.Tracing.Exporter = "http"
+				c.Tracing.Endpoint = "collector:4318"
+			},
+			want: []string{"TRACING_ENDPOINT must be an absolute URL"},
+		},
+		{
+			name:   "compression level out of range",
+			mutate: func(c *Config) { c.Compression.Level = 10 },
+			want:   []string{"COMPRESSION_LEVEL must be between -1 and 9"},
+		},
+		{
+			name: "wildcard origin with credentials",
+			mutate: func(c *Config) {
+				c.CORS.AllowedOrigins = []string{"*"}
+				c.CORS.AllowCredentials = true
+			},
+			want: []string{"CORS_ALLOWED_ORIGINS cannot be *"},
+		},
+		{
+			name:   "origin with a path",
+			mutate: func(c *Config) { c.CORS.AllowedOrigins = []string{"https://app.example.com/login"} },
+			want:   []string{"CORS_ALLOWED_ORIGINS entries must be scheme://host[:port]"},
+		},
+		{
+			name:   "short maintenance retry",
+			mutate: func(c *Config) { c.Maintenance.RetryAfter = time.Millisecond },
+			want:   []string{"MAINTENANCE_RETRY_AFTER must be at least 1s"},
+		},
+		{
+			name: "bad log settings",
+			mutate: func(c *Config) {
+				c.Log.Level = "loud"
+				c.Log.Format = "xml"
+			},
+			want: []string{"LOG_LEVEL", "LOG_FORMAT must be one of"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig(t)
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate() error = %v, want *ValidationError", err)
+			}
+			if len(verr.Problems) != len(tt.want) {
+				t.Fatalf("Validate() problems = %q, want %d", verr.Problems, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(verr.Problems[i], want) {
+					t.Errorf("problem %d = %q, want it to contain %q", i, verr.Problems[i], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+)
+
+// ConnString builds the libpq-style URL used by both the API (pgx) and the
+// migrator (lib/pq), so TLS and connection settings apply to both.
+func (d *DB) ConnString() string {
+	q := url.Values{}
+	q.Set("sslmode", d.SSLMode)
+	if d.SSLRootCert != "" {
+		q.Set("sslrootcert", d.SSLRootCert)
+	}
+	if d.SSLCert != "" {
+		q.Set("sslcert", d.SSLCert)
+	}
+	if d.SSLKey != "" {
+		q.Set("sslkey", d.SSLKey)
+	}
+	if d.ConnectTimeout > 0 {
+		q.Set("connect_timeout", strconv.Itoa(int(d.ConnectTimeout.Seconds())))
+	}
+	if d.ApplicationName != "" {
+		q.Set("application_name", d.ApplicationName)
+	}
+
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(d.Username, d.Password),
+		Host:     net.JoinHostPort(d.Host, d.Port),
+		Path:     "/" + d.DBName,
+		RawQuery: q.Encode(),
+	}
+
+	return u.String()
+}
+
+// ReplicaConnString is ConnString pointed at the read replica.
+func (d *DB) ReplicaConnString() string {
+	replica := *d
+	replica.Host = d.ReplicaHost
+	if d.ReplicaPort != "" {
+		replica.Port = d.ReplicaPort
+	}
+
+	return replica.ConnString()
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// secretKeys may also be given as KEY_FILE naming a file with the value, which
+// is how Docker and Kubernetes secrets are usually mounted.
+var secretKeys = []string{
+	"DB_PASSWORD",
+	"AUTH_JWT_SECRET",
+	"AUTH_API_KEYS",
+}
+
+// mapKeys hold key:value lists, so a nested object under them is kept as a map
+// rather than flattened into separate variables.
+var mapKeys = map[string]bool{
+	"AUTH_API_KEYS": true,
+}
+
+// buildEnvironment merges the config file with the process environment, the
+// latter taking precedence, and resolves *_FILE secrets.
+func buildEnvironment(path string) (map[string]string, error) {
+	environment := make(map[string]string)
+
+	if path != "" {
+		values, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(environment, values)
+	}
+
+	osEnv := make(map[string]string)
+	for _, kv := range os.Environ() {
+		key, value, _ := strings.Cut(kv, "=")
+		osEnv[key] = value
+	}
+	maps.Copy(environment, osEnv)
+
+	for _, key := range secretKeys {
+		secretPath := environment[key+"_FILE"]
+		if secretPath == "" {
+			continue
+		}
+		if _, ok := osEnv[key]; ok {
+			continue
+		}
+
+		data, err := os.ReadFile(secretPath)
+		if err != nil {
+			return nil, fmt.Errorf("read %s_FILE: %w", key, err)
+		}
+		environment[key] = strings.TrimRight(string(data), "\r\n")
+	}
+
+	return environment, nil
+}
+
+// readFile loads a YAML or JSON config file. Keys are the environment variable
+// names, case-insensitive; nested objects are joined with underscores, so
+// `db: {host: x}` is the same as `DB_HOST: x`. Lists become comma-separated.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	raw := make(map[string]any)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".json":
+		err = json.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q, want .yaml, .yml or .json", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	flatten("", raw, values)
+
+	return values, nil
+}
+
+func flatten(prefix string, raw map[string]any, out map[string]string) {
+	for k, v := range raw {
+		key := strings.ToUpper(k)
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+
+		if nested, ok := v.(map[string]any); ok {
+			if mapKeys[key] {
+				pairs := make([]string, 0, len(nested))
+				for mk, mv := range nested {
+					pairs = append(pairs, mk+":"+scalar(mv))
+				}
+				out[key] = strings.Join(pairs, ",")
+				continue
+			}
+
+			flatten(key, nested, out)
+			continue
+		}
+
+		out[key] = scalar(v)
+	}
+}
+
+func scalar(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, scalar(item))
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
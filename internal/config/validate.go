@@ -0,0 +1,233 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	sslModes        = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	roles           = []string{"reader", "editor", "admin"}
+	exporters       = []string{"file", "http"}
+	logFormats      = []string{"text", "json"}
+	tlsVersions     = []string{"1.2", "1.3"}
+	clientAuthModes = []string{"require", "verify_if_given"}
+)
+
+// ValidationError lists every problem found in a Config.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+type validator struct {
+	problems []string
+}
+
+func (v *validator) addf(format string, args ...any) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+func (v *validator) required(key, value string) {
+	if value == "" {
+		v.addf("%s is required", key)
+	}
+}
+
+func (v *validator) port(key, value string) {
+	if value == "" {
+		return
+	}
+	if p, err := strconv.Atoi(value); err != nil || p < 1 || p > 65535 {
+		v.addf("%s must be a port number, got %q", key, value)
+	}
+}
+
+func (v *validator) file(key, path string) {
+	if path == "" {
+		return
+	}
+	if _, err := os.Stat(path); err != nil {
+		v.addf("%s: %v", key, err)
+	}
+}
+
+func (v *validator) positive(key string, value float64) {
+	if value <= 0 {
+		v.addf("%s must be positive, got %v", key, value)
+	}
+}
+
+func (v *validator) oneOf(key, value string, allowed []string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.addf("%s must be one of %s, got %q", key, strings.Join(allowed, ", "), value)
+}
+
+// Validate checks the whole configuration and reports all problems at once.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	v.required("DB_USERNAME", c.DB.Username)
+	v.required("DB_HOST", c.DB.Host)
+	v.required("DB_PORT", c.DB.Port)
+	v.port("DB_PORT", c.DB.Port)
+	v.required("DB_NAME", c.DB.DBName)
+	v.required("DB_PASSWORD", c.DB.Password)
+	v.oneOf("SSL_MODE", c.DB.SSLMode, sslModes)
+	v.file("DB_SSL_ROOT_CERT", c.DB.SSLRootCert)
+	v.file("DB_SSL_CERT", c.DB.SSLCert)
+	v.file("DB_SSL_KEY", c.DB.SSLKey)
+	if (c.DB.SSLCert == "") != (c.DB.SSLKey == "") {
+		v.addf("DB_SSL_CERT and DB_SSL_KEY must be set together")
+	}
+	if c.DB.SSLMode == "disable" && (c.DB.SSLRootCert != "" || c.DB.SSLCert != "") {
+		v.addf("DB TLS certificates are set but SSL_MODE is disable")
+	}
+	if c.DB.ConnectTimeout < time.Second {
+		v.addf("DB_CONNECT_TIMEOUT must be at least 1s, got %s", c.DB.ConnectTimeout)
+	}
+	if c.DB.ConnectAttempts < 1 {
+		v.addf("DB_CONNECT_ATTEMPTS must be at least 1, got %d", c.DB.ConnectAttempts)
+	}
+	v.positive("DB_CONNECT_BACKOFF", c.DB.ConnectBackoff.Seconds())
+	if c.DB.ConnectMaxBackoff < c.DB.ConnectBackoff {
+		v.addf("DB_CONNECT_MAX_BACKOFF must not be less than DB_CONNECT_BACKOFF")
+	}
+	v.positive("DB_MAX_CONNS", float64(c.DB.MaxConns))
+	if c.DB.MinConns < 0 || c.DB.MinConns > c.DB.MaxConns {
+		v.addf("DB_MIN_CONNS must be between 0 and DB_MAX_CONNS, got %d", c.DB.MinConns)
+	}
+	v.positive("DB_MAX_CONN_LIFETIME", c.DB.MaxConnLifetime.Seconds())
+	v.positive("DB_MAX_CONN_IDLE_TIME", c.DB.MaxConnIdleTime.Seconds())
+	v.positive("DB_HEALTH_CHECK_PERIOD", c.DB.HealthCheckPeriod.Seconds())
+	v.port("DB_REPLICA_PORT", c.DB.ReplicaPort)
+	if c.DB.ReplicaPort != "" && c.DB.ReplicaHost == "" {
+		v.addf("DB_REPLICA_PORT requires DB_REPLICA_HOST")
+	}
+
+	v.required("SERV_PORT", c.Server.Port)
+	v.port("SERV_PORT", c.Server.Port)
+	v.positive("READ_TIME", c.Server.ReadTime.Seconds())
+	v.positive("WRITE_TIME", c.Server.WriteTime.Seconds())
+	v.positive("IDLE_TIMEOUT", c.Server.IdleTimeout.Seconds())
+	v.positive("READ_HEADER_TIMEOUT", c.Server.ReadHeaderTimeout.Seconds())
+	v.positive("MAX_HEADER_BYTES", float64(c.Server.MaxHeaderBytes))
+	v.positive("SHUTDOWN_TIMEOUT", c.Server.ShutdownTimeout.Seconds())
+	if c.Server.DrainDelay < 0 || c.Server.DrainDelay >= c.Server.ShutdownTimeout {
+		v.addf("DRAIN_DELAY must be between 0 and SHUTDOWN_TIMEOUT")
+	}
+	if (c.Server.TLSCertFile == "") != (c.Server.TLSKeyFile == "") {
+		v.addf("SERV_TLS_CERT and SERV_TLS_KEY must be set together")
+	}
+	if c.Server.TLSCertFile != "" {
+		v.file("SERV_TLS_CERT", c.Server.TLSCertFile)
+		v.file("SERV_TLS_KEY", c.Server.TLSKeyFile)
+		v.file("SERV_TLS_CLIENT_CA", c.Server.TLSClientCA)
+		v.oneOf("SERV_TLS_MIN_VERSION", c.Server.TLSMinVersion, tlsVersions)
+		v.oneOf("SERV_TLS_CLIENT_AUTH", c.Server.TLSClientAuth, clientAuthModes)
+		v.positive("SERV_TLS_RELOAD_INTERVAL", c.Server.TLSReloadInterval.Seconds())
+	} else if c.Server.TLSClientCA != "" {
+		v.addf("SERV_TLS_CLIENT_CA requires SERV_TLS_CERT and SERV_TLS_KEY")
+	}
+
+	v.required("ADMIN_PORT", c.Admin.Port)
+	v.port("ADMIN_PORT", c.Admin.Port)
+	if c.Admin.Port == c.Server.Port && c.Admin.Host == c.Server.Host {
+		v.addf("ADMIN_PORT must differ from SERV_PORT")
+	}
+	v.positive("ADMIN_WRITE_TIME", c.Admin.WriteTime.Seconds())
+
+	for _, role := range c.Auth.APIKeys {
+		v.oneOf("AUTH_API_KEYS role", role, roles)
+	}
+	if c.Auth.JWTSecret != "" && len(c.Auth.JWTSecret) < 32 {
+		v.addf("AUTH_JWT_SECRET must be at least 32 bytes for HS256")
+	}
+
+	if c.RateLimit.Enabled {
+		v.positive("RATE_LIMIT_READ_RPS", c.RateLimit.ReadRPS)
+		v.positive("RATE_LIMIT_READ_BURST", float64(c.RateLimit.ReadBurst))
+		v.positive("RATE_LIMIT_WRITE_RPS", c.RateLimit.WriteRPS)
+		v.positive("RATE_LIMIT_WRITE_BURST", float64(c.RateLimit.WriteBurst))
+		v.positive("RATE_LIMIT_IDLE_TTL", c.RateLimit.IdleTTL.Seconds())
+	}
+
+	if c.Tracing.Enabled {
+		v.oneOf("TRACING_EXPORTER", c.Tracing.Exporter, exporters)
+		switch c.Tracing.Exporter {
+		case "file":
+			v.required("TRACING_FILE_PATH", c.Tracing.FilePath)
+		case "http":
+			if u, err := url.Parse(c.Tracing.Endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+				v.addf("TRACING_ENDPOINT must be an absolute URL, got %q", c.Tracing.Endpoint)
+			}
+		}
+		if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+			v.addf("TRACING_SAMPLE_RATIO must be between 0 and 1, got %v", c.Tracing.SampleRatio)
+		}
+		v.positive("TRACING_BATCH_SIZE", float64(c.Tracing.BatchSize))
+		v.positive("TRACING_FLUSH_INTERVAL", c.Tracing.FlushInterval.Seconds())
+		v.positive("TRACING_EXPORT_TIMEOUT", c.Tracing.ExportTimeout.Seconds())
+	}
+
+	v.positive("IDEMPOTENCY_TTL", c.Idempotency.TTL.Seconds())
+	v.positive("IDEMPOTENCY_CLEANUP_INTERVAL", c.Idempotency.CleanupInterval.Seconds())
+	v.positive("IDEMPOTENCY_MAX_BODY_SIZE", float64(c.Idempotency.MaxBodySize))
+
+	if c.Compression.MinSize < 0 {
+		v.addf("COMPRESSION_MIN_SIZE must not be negative, got %d", c.Compression.MinSize)
+	}
+	if c.Compression.Level < -1 || c.Compression.Level > 9 {
+		v.addf("COMPRESSION_LEVEL must be between -1 and 9, got %d", c.Compression.Level)
+	}
+	v.positive("COMPRESSION_MAX_REQUEST_SIZE", float64(c.Compression.MaxRequestSize))
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			if c.CORS.AllowCredentials {
+				v.addf("CORS_ALLOWED_ORIGINS cannot be * when CORS_ALLOW_CREDENTIALS is set")
+			}
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			v.addf("CORS_ALLOWED_ORIGINS entries must be scheme://host[:port], got %q", origin)
+		}
+	}
+	if c.CORS.MaxAge < 0 {
+		v.addf("CORS_MAX_AGE must not be negative, got %s", c.CORS.MaxAge)
+	}
+
+	if c.Maintenance.RetryAfter < time.Second {
+		v.addf("MAINTENANCE_RETRY_AFTER must be at least 1s, got %s", c.Maintenance.RetryAfter)
+	}
+	v.required("MAINTENANCE_MESSAGE", c.Maintenance.Message)
+
+	v.positive("BULK_MAX_AFFECTED", float64(c.Bulk.MaxAffected))
+
+	if _, err := logrus.ParseLevel(c.Log.Level); err != nil {
+		v.addf("LOG_LEVEL: %v", err)
+	}
+	v.oneOf("LOG_FORMAT", c.Log.Format, logFormats)
+	if c.Log.LyricsMaxLen < 0 {
+		v.addf("LOG_LYRICS_MAX_LEN must not be negative, got %d", c.Log.LyricsMaxLen)
+	}
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+
+	return nil
+}
@@ -1,9 +1,11 @@
 package model
 
 type AddSong struct {
-	Song        string `json:"song" binding:"required"`
-	Group       string `json:"group" binding:"required"`
-	ReleaseDate string `json:"releaseDate" binding:"required"`
-	Link        string `json:"link" binding:"required"`
-	Text        string `json:"text" binding:"required"`
+	Song        string `json:"song" validate:"required"`
+	Group       string `json:"group" validate:"required"`
+	ReleaseDate string `json:"releaseDate" validate:"required"`
+	Link        string `json:"link" validate:"required"`
+	Text        string `json:"text" validate:"required"`
+	// Artists credits further groups besides Group.
+	Artists []AddCredit `json:"artists,omitempty"`
 }
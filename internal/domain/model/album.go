@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+type Album struct {
+	ID          uint64       `json:"id"`
+	Title       string       `json:"title"`
+	GroupID     uint64       `json:"groupId"`
+	Group       string       `json:"group"`
+	ReleaseDate string       `json:"releaseDate"`
+	CoverLink   string       `json:"coverLink"`
+	Tracks      []AlbumTrack `json:"tracks,omitempty"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+}
+
+// AlbumTrack is a song at a 1-based position on an album.
+type AlbumTrack struct {
+	Position int    `json:"position"`
+	SongID   uint64 `json:"songId"`
+	Song     string `json:"song"`
+}
+
+type AddAlbum struct {
+	Title       string `json:"title" validate:"required"`
+	GroupID     uint64 `json:"groupId" validate:"required"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+	CoverLink   string `json:"coverLink,omitempty"`
+	// Tracks lists song IDs in track order.
+	Tracks []uint64 `json:"tracks,omitempty"`
+}
+
+// UpdateAlbum changes only the fields that are set. The track list is
+// replaced through SetAlbumTracks.
+type UpdateAlbum struct {
+	ID          uint64 `json:"-"`
+	Title       string `json:"title,omitempty"`
+	GroupID     uint64 `json:"groupId,omitempty"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+	CoverLink   string `json:"coverLink,omitempty"`
+}
+
+// SetAlbumTracks replaces the whole track list of an album.
+type SetAlbumTracks struct {
+	AlbumID uint64   `json:"-"`
+	Tracks  []uint64 `json:"tracks"`
+}
+
+type AlbumsRequest struct {
+	GroupID uint64 `json:"group_id,omitempty"`
+	Page    int    `json:"page"`
+	PerPage int    `json:"per_page"`
+}
@@ -0,0 +1,47 @@
+package model
+
+import "errors"
+
+const (
+	BulkDelete      = "delete"
+	BulkSetField    = "set-field"
+	BulkRenameGroup = "rename-group"
+)
+
+// ErrBulkLimitExceeded is returned by the repository when a bulk operation
+// matches more songs than allowed and was not forced. Nothing is changed.
+var ErrBulkLimitExceeded = errors.New("bulk operation matches too many songs")
+
+type BulkRequest struct {
+	Operation string        `json:"operation"`
+	Filter    LibraryFilter `json:"filter"`
+	// Field and Value are used by set-field.
+	Field string `json:"field,omitempty"`
+	Value string `json:"value,omitempty"`
+	// NewGroup is used by rename-group.
+	NewGroup string `json:"newGroup,omitempty"`
+
+	DryRun bool `json:"-" form:"dry_run"`
+	Force  bool `json:"-" form:"force"`
+}
+
+// BulkOperation is a validated BulkRequest as the repository runs it.
+// MaxAffected and MaxReportedIDs of 0 mean no limit.
+type BulkOperation struct {
+	Operation      string
+	Filter         LibraryFilter
+	Field          string
+	Value          string
+	DryRun         bool
+	MaxAffected    int
+	MaxReportedIDs int
+}
+
+type BulkResult struct {
+	Operation string `json:"operation"`
+	DryRun    bool   `json:"dryRun"`
+	Matched   int    `json:"matched"`
+	Affected  int    `json:"affected"`
+	// IDs lists the lowest matching ids, at most MaxReportedIDs of them.
+	IDs []uint64 `json:"ids"`
+}
@@ -0,0 +1,24 @@
+package model
+
+const (
+	CreditPrimary  = "primary"
+	CreditFeatured = "featured"
+	CreditRemixer  = "remixer"
+)
+
+// CreditRoles lists the roles an artist can be credited with on a song.
+var CreditRoles = []string{CreditPrimary, CreditFeatured, CreditRemixer}
+
+// ArtistCredit is a group credited on a song. The song's own group is
+// always credited first as primary.
+type ArtistCredit struct {
+	GroupID uint64 `json:"groupId"`
+	Name    string `json:"name"`
+	Role    string `json:"role"`
+}
+
+// AddCredit credits a group by name, creating it if needed.
+type AddCredit struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
@@ -0,0 +1,11 @@
+package model
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when the addressed entity does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when a change clashes with existing data, such
+	// as a duplicate name or a delete that other rows still reference.
+	ErrConflict = errors.New("conflict")
+)
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+type Group struct {
+	ID          uint64    `json:"id"`
+	Name        string    `json:"name"`
+	Country     string    `json:"country"`
+	FormedYear  *int      `json:"formedYear,omitempty"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type AddGroup struct {
+	Name        string `json:"name" validate:"required"`
+	Country     string `json:"country,omitempty"`
+	FormedYear  *int   `json:"formedYear,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// UpdateGroup changes only the fields that are set.
+type UpdateGroup struct {
+	ID          uint64 `json:"-"`
+	Name        string `json:"name,omitempty"`
+	Country     string `json:"country,omitempty"`
+	FormedYear  *int   `json:"formedYear,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type GroupsRequest struct {
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+}
+
+type GroupSongsRequest struct {
+	GroupID uint64 `json:"group_id"`
+	Page    int    `json:"page"`
+	PerPage int    `json:"per_page"`
+}
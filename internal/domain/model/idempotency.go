@@ -0,0 +1,15 @@
+package model
+
+type IdempotencyRecord struct {
+	Scope       string
+	Key         string
+	RequestHash string
+	// Status is zero while the original request is still being processed.
+	Status      int
+	ContentType string
+	Body        []byte
+}
+
+func (r IdempotencyRecord) Completed() bool {
+	return r.Status != 0
+}
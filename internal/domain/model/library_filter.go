@@ -1,8 +1,42 @@
 package model
 
 type LibraryFilter struct {
-	Group   string `json:"group,omitempty"`
-	Song    string `json:"song,omitempty"`
-	Page    int    `json:"page"`
-	PerPage int    `json:"per_page"`
+	Group string `json:"group,omitempty"`
+	Song  string `json:"song,omitempty"`
+	// Album matches songs on any album with this title.
+	Album string `json:"album,omitempty"`
+	// The people filters match songs crediting a person with this name in
+	// the role.
+	Lyricist string `json:"lyricist,omitempty"`
+	Composer string `json:"composer,omitempty"`
+	Producer string `json:"producer,omitempty"`
+	Arranger string `json:"arranger,omitempty"`
+	// Tags matches songs carrying all of these tags, or any of them when
+	// TagsMatch is "any".
+	Tags      []string `json:"tags,omitempty"`
+	TagsMatch string   `json:"tagsMatch,omitempty"`
+	Page      int      `json:"page"`
+	PerPage   int      `json:"per_page"`
+}
+
+// Empty reports whether the filter matches every song.
+func (f LibraryFilter) Empty() bool {
+	return f.Group == "" && f.Song == "" && f.Album == "" && len(f.People()) == 0 && len(f.Tags) == 0
+}
+
+// People returns the set people filters keyed by role.
+func (f LibraryFilter) People() map[string]string {
+	people := map[string]string{}
+	for role, name := range map[string]string{
+		PersonLyricist: f.Lyricist,
+		PersonComposer: f.Composer,
+		PersonProducer: f.Producer,
+		PersonArranger: f.Arranger,
+	} {
+		if name != "" {
+			people[role] = name
+		}
+	}
+
+	return people
 }
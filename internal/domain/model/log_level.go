@@ -0,0 +1,5 @@
+package model
+
+type LogLevel struct {
+	Level string `json:"level" binding:"required"`
+}
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type MaintenanceStatus struct {
+	ReadOnly bool       `json:"read_only"`
+	Since    *time.Time `json:"since,omitempty"`
+}
+
+type SetMaintenance struct {
+	ReadOnly *bool `json:"read_only" binding:"required"`
+}
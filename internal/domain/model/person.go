@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+const (
+	PersonLyricist = "lyricist"
+	PersonComposer = "composer"
+	PersonProducer = "producer"
+	PersonArranger = "arranger"
+)
+
+// PersonRoles lists the roles a person can be credited with on a song.
+var PersonRoles = []string{PersonLyricist, PersonComposer, PersonProducer, PersonArranger}
+
+type Person struct {
+	ID        uint64    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type AddPerson struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type UpdatePerson struct {
+	ID   uint64 `json:"-"`
+	Name string `json:"name" validate:"required"`
+}
+
+// PersonCredit is a person credited on a song in one role.
+type PersonCredit struct {
+	PersonID uint64 `json:"personId"`
+	Name     string `json:"name"`
+	Role     string `json:"role"`
+}
+
+// AddPersonCredit credits a person by name, creating them if needed.
+type AddPersonCredit struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// SetSongCredits replaces all person credits of a song.
+type SetSongCredits struct {
+	SongID  uint64            `json:"-"`
+	Credits []AddPersonCredit `json:"credits"`
+}
+
+type PeopleRequest struct {
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+}
+
+// PersonSongsRequest lists the songs a person is credited on, optionally
+// only in one role.
+type PersonSongsRequest struct {
+	PersonID uint64 `json:"person_id"`
+	Role     string `json:"role,omitempty"`
+	Page     int    `json:"page"`
+	PerPage  int    `json:"per_page"`
+}
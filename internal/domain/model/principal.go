@@ -0,0 +1,6 @@
+package model
+
+type Principal struct {
+	Subject string `json:"subject"`
+	Role    Role   `json:"role"`
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// LyricsLogLimit caps how many characters of lyric text the String methods
+// below reveal. Zero hides the text entirely.
+var LyricsLogLimit = 32
+
+func redactLyrics(text string) string {
+	n := utf8.RuneCountInString(text)
+	if n <= LyricsLogLimit {
+		return text
+	}
+
+	runes := []rune(text)
+	return fmt.Sprintf("%s...(%d chars)", string(runes[:LyricsLogLimit]), n)
+}
+
+// The String methods make %v and %+v safe to use in logs without dumping
+// whole lyrics.
+
+func (s Song) String() string {
+	return fmt.Sprintf("{ID:%d Song:%s Group:%s ReleaseDate:%s Link:%s Text:%s Artists:%v}",
+		s.ID, s.Song, s.Group, s.ReleaseDate, s.Link, redactLyrics(s.Text), s.Artists)
+}
+
+func (s AddSong) String() string {
+	return fmt.Sprintf("{Song:%s Group:%s ReleaseDate:%s Link:%s Text:%s Artists:%v}",
+		s.Song, s.Group, s.ReleaseDate, s.Link, redactLyrics(s.Text), s.Artists)
+}
+
+func (s UpdateSong) String() string {
+	var artists any = "<unchanged>"
+	if s.Artists != nil {
+		artists = *s.Artists
+	}
+
+	return fmt.Sprintf("{ID:%d Song:%s Group:%s ReleaseDate:%s Link:%s Text:%s Artists:%v}",
+		s.ID, s.Song, s.Group, s.ReleaseDate, s.Link, redactLyrics(s.Text), artists)
+}
+
+func (s SongDetails) String() string {
+	return fmt.Sprintf("{ReleaseDate:%s Text:%s Link:%s Artists:%v}", s.ReleaseDate, redactLyrics(s.Text), s.Link, s.Artists)
+}
+
+func (v VersesResponse) String() string {
+	return fmt.Sprintf("{SongID:%d Page:%d PerPage:%d Verses:%d}", v.SongID, v.Page, v.PerPage, len(v.Verses))
+}
+
+func (r BulkRequest) String() string {
+	return fmt.Sprintf("{Operation:%s Filter:%+v Field:%s Value:%s NewGroup:%s DryRun:%t Force:%t}",
+		r.Operation, r.Filter, r.Field, redactLyrics(r.Value), r.NewGroup, r.DryRun, r.Force)
+}
+
+func (o BulkOperation) String() string {
+	return fmt.Sprintf("{Operation:%s Filter:%+v Field:%s Value:%s DryRun:%t MaxAffected:%d}",
+		o.Operation, o.Filter, o.Field, redactLyrics(o.Value), o.DryRun, o.MaxAffected)
+}
@@ -0,0 +1,54 @@
+package model
+
+// A relation points from a song to the one it derives from: a cover is
+// cover_of its original.
+const (
+	RelationCoverOf       = "cover_of"
+	RelationRemixOf       = "remix_of"
+	RelationLiveVersionOf = "live_version_of"
+	RelationTranslationOf = "translation_of"
+)
+
+// RelationTypes lists the supported relation types.
+var RelationTypes = []string{RelationCoverOf, RelationRemixOf, RelationLiveVersionOf, RelationTranslationOf}
+
+const (
+	// RelationOutgoing relations start at the song, e.g. the original it
+	// covers.
+	RelationOutgoing = "outgoing"
+	// RelationIncoming relations end at the song, e.g. its covers.
+	RelationIncoming = "incoming"
+)
+
+// SongRelation is a song related to the one asked about. Depth counts the
+// relations followed to reach it.
+type SongRelation struct {
+	Type      string `json:"type"`
+	Direction string `json:"direction"`
+	Depth     int    `json:"depth"`
+	SongID    uint64 `json:"songId"`
+	Song      string `json:"song"`
+	Group     string `json:"group"`
+}
+
+// SongRelationsRequest traverses the relations of a song. Empty Type and
+// Direction match all; Depth follows chains such as a cover of a cover.
+type SongRelationsRequest struct {
+	SongID    uint64 `json:"song_id"`
+	Type      string `json:"type,omitempty"`
+	Direction string `json:"direction,omitempty"`
+	Depth     int    `json:"depth"`
+}
+
+// AddSongRelation records that the song is Type of the related song.
+type AddSongRelation struct {
+	SongID        uint64 `json:"-"`
+	Type          string `json:"type"`
+	RelatedSongID uint64 `json:"relatedSongId"`
+}
+
+type DeleteSongRelation struct {
+	SongID        uint64
+	Type          string
+	RelatedSongID uint64
+}
@@ -0,0 +1,42 @@
+package model
+
+import "fmt"
+
+type Role int
+
+const (
+	RoleReader Role = iota + 1
+	RoleEditor
+	RoleAdmin
+)
+
+func ParseRole(s string) (Role, error) {
+	switch s {
+	case "reader":
+		return RoleReader, nil
+	case "editor":
+		return RoleEditor, nil
+	case "admin":
+		return RoleAdmin, nil
+	}
+
+	return 0, fmt.Errorf("unknown role %q", s)
+}
+
+func (r Role) String() string {
+	switch r {
+	case RoleReader:
+		return "reader"
+	case RoleEditor:
+		return "editor"
+	case RoleAdmin:
+		return "admin"
+	}
+
+	return "unknown"
+}
+
+// Allows reports whether r grants at least the permissions of required.
+func (r Role) Allows(required Role) bool {
+	return r >= required
+}
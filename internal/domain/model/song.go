@@ -1,10 +1,20 @@
 package model
 
+import "time"
+
 type Song struct {
-	ID          uint64 `json:"id"`
-	Song        string `json:"song"`
-	Group       string `json:"group"`
-	ReleaseDate string `json:"releaseDate"`
-	Link        string `json:"link"`
-	Text        string `json:"text"`
+	ID          uint64    `json:"id"`
+	Song        string    `json:"song"`
+	Group       string    `json:"group"`
+	GroupID     uint64    `json:"groupId"`
+	ReleaseDate string    `json:"releaseDate"`
+	Link        string    `json:"link"`
+	Text        string    `json:"text"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	// Artists lists every credited group. When writing, it holds only the
+	// credits besides Group; nil leaves them unchanged on update.
+	Artists []ArtistCredit `json:"artists"`
+	// Relations is only filled in on single-song responses.
+	Relations []SongRelation `json:"relations,omitempty"`
 }
@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+type ChangesRequest struct {
+	Since string `json:"since"`
+	Limit int    `json:"limit"`
+}
+
+type SongTombstone struct {
+	ID        uint64    `json:"id"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+type SongChanges struct {
+	Upserts   []Song          `json:"upserts"`
+	Deletions []SongTombstone `json:"deletions"`
+	// NextToken is passed as since on the next call.
+	NextToken string `json:"nextToken"`
+	HasMore   bool   `json:"hasMore"`
+}
+
+// ChangeCursor points at a change in the feed. Changes are ordered by the
+// transaction that wrote them, then by sequence number within it.
+type ChangeCursor struct {
+	XID uint64
+	Seq uint64
+}
+
+// Less reports whether c comes before other in the feed.
+func (c ChangeCursor) Less(other ChangeCursor) bool {
+	if c.XID != other.XID {
+		return c.XID < other.XID
+	}
+	return c.Seq < other.Seq
+}
+
+// ChangeSet is what the repository returns: changes after a cursor in order,
+// with the cursor of the last one included.
+type ChangeSet struct {
+	Upserts   []Song
+	Deletions []SongTombstone
+	Last      ChangeCursor
+	HasMore   bool
+}
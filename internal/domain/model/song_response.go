@@ -1,7 +1,9 @@
 package model
 
 type SongDetails struct {
-	ReleaseDate string `json:"releaseDate"`
-	Text        string `json:"text"`
-	Link        string `json:"link"`
+	ID          uint64         `json:"-"`
+	ReleaseDate string         `json:"releaseDate"`
+	Text        string         `json:"text"`
+	Link        string         `json:"link"`
+	Artists     []ArtistCredit `json:"artists"`
 }
@@ -0,0 +1,34 @@
+package model
+
+const (
+	TagsMatchAll = "all"
+	TagsMatchAny = "any"
+)
+
+// TagCount is a tag with the number of songs carrying it.
+type TagCount struct {
+	Name  string `json:"name"`
+	Songs int    `json:"songs"`
+}
+
+type TagsRequest struct {
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+}
+
+// SongTags lists the tags of a song by name.
+type SongTags struct {
+	SongID uint64   `json:"songId"`
+	Tags   []string `json:"tags"`
+}
+
+// AttachTags adds tags to a song, creating tags named for the first time.
+type AttachTags struct {
+	SongID uint64   `json:"-"`
+	Tags   []string `json:"tags"`
+}
+
+type DetachTag struct {
+	SongID uint64
+	Tag    string
+}
@@ -7,11 +7,15 @@ type UpdateSong struct {
 	ReleaseDate string `json:"releaseDate,omitempty"`
 	Link        string `json:"link,omitempty"`
 	Text        string `json:"text,omitempty"`
+	// Artists replaces the credits besides Group when set; an empty list
+	// removes them.
+	Artists *[]AddCredit `json:"artists,omitempty"`
 }
 
 type UpdateSongSwagger struct {
-	Song        string `json:"song,omitempty"`
-	Group       string `json:"group,omitempty"`
-	ReleaseDate string `json:"releaseDate,omitempty"`
-	Link        string `json:"link,omitempty"`
+	Song        string      `json:"song,omitempty"`
+	Group       string      `json:"group,omitempty"`
+	ReleaseDate string      `json:"releaseDate,omitempty"`
+	Link        string      `json:"link,omitempty"`
+	Artists     []AddCredit `json:"artists,omitempty"`
 }
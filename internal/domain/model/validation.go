@@ -0,0 +1,23 @@
+package model
+
+import "strings"
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned by usecases when the input breaks one or more
+// field rules. Handlers turn it into a 422 listing every field.
+type ValidationError struct {
+	Errors []FieldError `json:"errors"`
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, fe := range e.Errors {
+		msgs = append(msgs, fe.Field+": "+fe.Message)
+	}
+
+	return "validation failed: " + strings.Join(msgs, "; ")
+}
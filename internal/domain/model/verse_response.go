@@ -1,8 +1,12 @@
 package model
 
+import "time"
+
 type VersesResponse struct {
 	SongID  uint64   `json:"song_id"`
 	Page    int      `json:"page"`
 	PerPage int      `json:"per_page"`
 	Verses  []string `json:"verses"`
+	// UpdatedAt drives Last-Modified; it is zero when no verses were found.
+	UpdatedAt time.Time `json:"-"`
 }
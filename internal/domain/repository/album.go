@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+)
+
+type Album interface {
+	List(ctx context.Context, request model.AlbumsRequest) ([]model.Album, error)
+	Get(ctx context.Context, id uint64) (model.Album, error)
+	Add(ctx context.Context, album model.AddAlbum) (model.Album, error)
+	Update(ctx context.Context, album model.UpdateAlbum) (model.Album, error)
+	SetTracks(ctx context.Context, request model.SetAlbumTracks) (model.Album, error)
+	Delete(ctx context.Context, id uint64) error
+}
@@ -0,0 +1,8 @@
+package repository
+
+import "context"
+
+type Health interface {
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (version uint, dirty bool, err error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"time"
+)
+
+type Idempotency interface {
+	// Reserve claims scope/key for a new request. If a live record already
+	// exists it is returned with created set to false.
+	Reserve(ctx context.Context, record model.IdempotencyRecord, ttl time.Duration) (existing model.IdempotencyRecord, created bool, err error)
+	Complete(ctx context.Context, record model.IdempotencyRecord) error
+	Release(ctx context.Context, scope, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+)
+
+type Person interface {
+	List(ctx context.Context, request model.PeopleRequest) ([]model.Person, error)
+	Get(ctx context.Context, id uint64) (model.Person, error)
+	Add(ctx context.Context, person model.AddPerson) (model.Person, error)
+	Update(ctx context.Context, person model.UpdatePerson) (model.Person, error)
+	Delete(ctx context.Context, id uint64) error
+	GetSongs(ctx context.Context, request model.PersonSongsRequest) ([]model.Song, error)
+	GetCredits(ctx context.Context, songID uint64) ([]model.PersonCredit, error)
+	SetCredits(ctx context.Context, request model.SetSongCredits) ([]model.PersonCredit, error)
+}
@@ -3,12 +3,16 @@ package repository
 import (
 	"context"
 	"song_lib/internal/domain/model"
+	"time"
 )
 
 type Song interface {
 	GetSongs(ctx context.Context, filter model.LibraryFilter) ([]model.SongDetails, error)
-	GetVerses(ctx context.Context, filter model.VersesRequest) ([]string, error)
+	GetVerses(ctx context.Context, filter model.VersesRequest) ([]string, time.Time, error)
+	Get(ctx context.Context, id uint64) (model.Song, error)
 	Add(ctx context.Context, song model.Song) (uint64, error)
 	Delete(ctx context.Context, id uint64) error
 	Update(ctx context.Context, song model.Song) (model.Song, error)
+	GetChanges(ctx context.Context, since model.ChangeCursor, limit int) (model.ChangeSet, error)
+	Bulk(ctx context.Context, op model.BulkOperation) (model.BulkResult, error)
 }
@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+)
+
+type Album interface {
+	List(ctx context.Context, request model.AlbumsRequest) ([]model.Album, error)
+	Get(ctx context.Context, id uint64) (model.Album, error)
+	Add(ctx context.Context, request model.AddAlbum) (model.Album, error)
+	Update(ctx context.Context, request model.UpdateAlbum) (model.Album, error)
+	SetTracks(ctx context.Context, request model.SetAlbumTracks) (model.Album, error)
+	Delete(ctx context.Context, id uint64) error
+}
@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+)
+
+type Group interface {
+	List(ctx context.Context, request model.GroupsRequest) ([]model.Group, error)
+	Get(ctx context.Context, id uint64) (model.Group, error)
+	Add(ctx context.Context, request model.AddGroup) (model.Group, error)
+	Update(ctx context.Context, request model.UpdateGroup) (model.Group, error)
+	Delete(ctx context.Context, id uint64) error
+	GetSongs(ctx context.Context, request model.GroupSongsRequest) ([]model.Song, error)
+}
@@ -0,0 +1,11 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+)
+
+type Health interface {
+	Readiness(ctx context.Context) model.Readiness
+	Drain()
+}
@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"time"
+)
+
+type Idempotency interface {
+	Begin(ctx context.Context, scope, key, requestHash string) (existing model.IdempotencyRecord, created bool, err error)
+	Complete(ctx context.Context, record model.IdempotencyRecord) error
+	Release(ctx context.Context, scope, key string) error
+	RunCleanup(interval time.Duration) func(ctx context.Context) error
+}
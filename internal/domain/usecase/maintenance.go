@@ -0,0 +1,10 @@
+package usecase
+
+import "song_lib/internal/domain/model"
+
+type Maintenance interface {
+	ReadOnly() bool
+	Status() model.MaintenanceStatus
+	SetReadOnly(readOnly bool) model.MaintenanceStatus
+	Toggle() model.MaintenanceStatus
+}
@@ -0,0 +1,12 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+)
+
+type Relation interface {
+	List(ctx context.Context, request model.SongRelationsRequest) ([]model.SongRelation, error)
+	Add(ctx context.Context, request model.AddSongRelation) (model.SongRelation, error)
+	Delete(ctx context.Context, request model.DeleteSongRelation) error
+}
@@ -8,7 +8,10 @@ import (
 type Song interface {
 	GetLib(ctx context.Context, request model.LibraryFilter) ([]model.SongDetails, error)
 	GetVerses(ctx context.Context, request model.VersesRequest) (model.VersesResponse, error)
+	Get(ctx context.Context, id uint64) (model.Song, error)
 	Delete(ctx context.Context, id uint64) error
 	Update(ctx context.Context, song model.UpdateSong) (model.Song, error)
 	Add(ctx context.Context, request model.AddSong) (uint64, error)
+	GetChanges(ctx context.Context, request model.ChangesRequest) (model.SongChanges, error)
+	Bulk(ctx context.Context, request model.BulkRequest) (model.BulkResult, error)
 }
@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+)
+
+type Tag interface {
+	List(ctx context.Context, request model.TagsRequest) ([]model.TagCount, error)
+	GetSongTags(ctx context.Context, songID uint64) (model.SongTags, error)
+	Attach(ctx context.Context, request model.AttachTags) (model.SongTags, error)
+	Detach(ctx context.Context, request model.DetachTag) (model.SongTags, error)
+}
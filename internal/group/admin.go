@@ -0,0 +1,108 @@
+package group
+
+import (
+	"net/http"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type Admin struct {
+	maintenanceUsecase usecase.Maintenance
+	log                *logrus.Logger
+}
+
+func NewAdmin(maintenanceUsecase usecase.Maintenance, log *logrus.Logger) *Admin {
+	return &Admin{
+		maintenanceUsecase: maintenanceUsecase,
+		log:                log,
+	}
+}
+
+// @Summary Get log level
+// @Tags admin
+// @Description Returns the current log level. Served on the admin listener only.
+// @ID get-log-level
+// @Produce json
+// @Success 200 {object} model.LogLevel "Current log level"
+// @Router /loglevel [get]
+func (a *Admin) GetLogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, model.LogLevel{Level: a.log.GetLevel().String()})
+}
+
+// @Summary Set log level
+// @Tags admin
+// @Description Changes the log level at runtime until the next restart. Served on the admin listener only.
+// @ID set-log-level
+// @Accept json
+// @Produce json
+// @Param level body model.LogLevel true "New log level"
+// @Success 200 {object} model.LogLevel "New log level"
+// @Failure 400 {string} string "Invalid log level"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /loglevel [put]
+func (a *Admin) SetLogLevel(c *gin.Context) {
+	log := a.log.WithContext(c).WithField("op", "internal/group/admin/SetLogLevel")
+
+	var req model.LogLevel
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error(err)
+		c.AbortWithStatusJSON(http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	level, err := logrus.ParseLevel(req.Level)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, "Invalid log level")
+		return
+	}
+
+	previous := a.log.GetLevel()
+	a.log.SetLevel(level)
+	log.Warnf("Log level changed from %s to %s", previous, level)
+
+	c.JSON(http.StatusOK, model.LogLevel{Level: level.String()})
+}
+
+// @Summary Get maintenance mode
+// @Tags admin
+// @Description Reports whether the API is in read-only maintenance mode. Served on the admin listener only.
+// @ID get-maintenance
+// @Produce json
+// @Success 200 {object} model.MaintenanceStatus "Maintenance status"
+// @Router /maintenance [get]
+func (a *Admin) GetMaintenance(c *gin.Context) {
+	c.JSON(http.StatusOK, a.maintenanceUsecase.Status())
+}
+
+// @Summary Set maintenance mode
+// @Tags admin
+// @Description Turns read-only maintenance mode on or off for this instance. Served on the admin listener only.
+// @ID set-maintenance
+// @Accept json
+// @Produce json
+// @Param maintenance body model.SetMaintenance true "Desired mode"
+// @Success 200 {object} model.MaintenanceStatus "Maintenance status"
+// @Failure 400 {string} string "Invalid request format"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /maintenance [put]
+func (a *Admin) SetMaintenance(c *gin.Context) {
+	log := a.log.WithContext(c).WithField("op", "internal/group/admin/SetMaintenance")
+
+	var req model.SetMaintenance
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error(err)
+		c.AbortWithStatusJSON(http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	c.JSON(http.StatusOK, a.maintenanceUsecase.SetReadOnly(*req.ReadOnly))
+}
@@ -0,0 +1,271 @@
+package group
+
+import (
+	"net/http"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/usecase"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type Album struct {
+	albumUsecase usecase.Album
+	log          *logrus.Logger
+}
+
+func NewAlbum(albumUsecase usecase.Album, log *logrus.Logger) *Album {
+	return &Album{
+		albumUsecase: albumUsecase,
+		log:          log,
+	}
+}
+
+// @Summary List albums
+// @Tags albums
+// @Description Lists albums without their tracks, optionally only those of one group
+// @ID list-albums
+// @Produce json
+// @Param group_id query int false "Group ID"
+// @Param page query int false "Page number" default(0)
+// @Param per_page query int false "Number of albums per page" default(10)
+// @Success 200 {array} model.Album "List of albums"
+// @Failure 400 {string} string "Invalid request format"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/albums [get]
+func (a *Album) List(c *gin.Context) {
+	log := a.log.WithContext(c).WithField("op", "internal/group/album/List")
+
+	page, perPage, ok := parsePagination(c, log)
+	if !ok {
+		return
+	}
+
+	var groupID uint64
+	if groupIDStr := c.Query("group_id"); groupIDStr != "" {
+		var err error
+		groupID, err = strconv.ParseUint(groupIDStr, 10, 64)
+		if err != nil {
+			log.WithError(err).Error("Invalid group_id parameter")
+			c.AbortWithStatusJSON(http.StatusBadRequest, "invalid group_id parameter")
+			return
+		}
+	}
+
+	albums, err := a.albumUsecase.List(c, model.AlbumsRequest{GroupID: groupID, Page: page, PerPage: perPage})
+	if err != nil {
+		abortWithError(c, log, err, "Failed to list albums")
+		return
+	}
+
+	log.Infof("Successfully fetched %d albums", len(albums))
+	c.JSON(http.StatusOK, albums)
+}
+
+// @Summary Get an album
+// @Tags albums
+// @Description Returns an album with its track list
+// @ID get-album
+// @Produce json
+// @Param id path int true "Album ID"
+// @Success 200 {object} model.Album "Album details"
+// @Failure 400 {string} string "Invalid album ID"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 404 {string} string "Album not found"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/albums/{id} [get]
+func (a *Album) Get(c *gin.Context) {
+	log := a.log.WithContext(c).WithField("op", "internal/group/album/Get")
+
+	id, ok := parseID(c, log, "album")
+	if !ok {
+		return
+	}
+
+	album, err := a.albumUsecase.Get(c, id)
+	if err != nil {
+		abortWithError(c, log, err, "Failed to fetch album")
+		return
+	}
+
+	c.JSON(http.StatusOK, album)
+}
+
+// @Summary Add an album
+// @Tags albums
+// @Description Creates an album of a group, optionally with its track list. Titles are unique per group.
+// @ID add-album
+// @Accept json
+// @Produce json
+// @Param album body model.AddAlbum true "Album details"
+// @Param Idempotency-Key header string false "Makes retries of this request safe"
+// @Success 201 {object} model.Album "Created album"
+// @Failure 400 {string} string "Incorrect fields"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 404 {string} string "Group or song not found"
+// @Failure 409 {string} string "Album already exists"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/albums [post]
+func (a *Album) Add(c *gin.Context) {
+	log := a.log.WithContext(c).WithField("op", "internal/group/album/Add")
+
+	input := model.AddAlbum{}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.WithError(err).Error("Incorrect fields in request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, "incorrect fields")
+		return
+	}
+
+	album, err := a.albumUsecase.Add(c, input)
+	if err != nil {
+		abortWithError(c, log, err, "Failed to add album")
+		return
+	}
+
+	log.Infof("Successfully added album with ID: %d", album.ID)
+	c.JSON(http.StatusCreated, album)
+}
+
+// @Summary Update an album
+// @Tags albums
+// @Description Updates the given fields of an album. The track list is left as is.
+// @ID update-album
+// @Accept json
+// @Produce json
+// @Param id path int true "Album ID"
+// @Param album body model.UpdateAlbum true "Fields to change"
+// @Param Idempotency-Key header string false "Makes retries of this request safe"
+// @Success 200 {object} model.Album "Updated album"
+// @Failure 400 {string} string "Incorrect fields"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 404 {string} string "Album or group not found"
+// @Failure 409 {string} string "Album title already taken"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/albums/{id} [put]
+func (a *Album) Update(c *gin.Context) {
+	log := a.log.WithContext(c).WithField("op", "internal/group/album/Update")
+
+	id, ok := parseID(c, log, "album")
+	if !ok {
+		return
+	}
+
+	input := model.UpdateAlbum{}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.WithError(err).Error("Incorrect fields in request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, "incorrect fields")
+		return
+	}
+	input.ID = id
+
+	album, err := a.albumUsecase.Update(c, input)
+	if err != nil {
+		abortWithError(c, log, err, "Failed to update album")
+		return
+	}
+
+	log.Infof("Successfully updated album with ID: %d", album.ID)
+	c.JSON(http.StatusOK, album)
+}
+
+// @Summary Replace an album's tracks
+// @Tags albums
+// @Description Replaces the whole track list. Song IDs are numbered from 1 in the given order.
+// @ID set-album-tracks
+// @Accept json
+// @Produce json
+// @Param id path int true "Album ID"
+// @Param tracks body model.SetAlbumTracks true "Song IDs in track order"
+// @Param Idempotency-Key header string false "Makes retries of this request safe"
+// @Success 200 {object} model.Album "Album with its new tracks"
+// @Failure 400 {string} string "Incorrect fields"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 404 {string} string "Album or song not found"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/albums/{id}/tracks [put]
+func (a *Album) SetTracks(c *gin.Context) {
+	log := a.log.WithContext(c).WithField("op", "internal/group/album/SetTracks")
+
+	id, ok := parseID(c, log, "album")
+	if !ok {
+		return
+	}
+
+	input := model.SetAlbumTracks{}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.WithError(err).Error("Incorrect fields in request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, "incorrect fields")
+		return
+	}
+	input.AlbumID = id
+
+	album, err := a.albumUsecase.SetTracks(c, input)
+	if err != nil {
+		abortWithError(c, log, err, "Failed to set album tracks")
+		return
+	}
+
+	log.Infof("Successfully set %d tracks on album ID: %d", len(album.Tracks), album.ID)
+	c.JSON(http.StatusOK, album)
+}
+
+// @Summary Delete an album
+// @Tags albums
+// @Description Deletes an album and its track list. The songs are kept.
+// @ID delete-album
+// @Produce json
+// @Param id path int true "Album ID"
+// @Success 200 {string} string "The album has been deleted"
+// @Failure 400 {string} string "Invalid album ID"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 404 {string} string "Album not found"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/albums/{id} [delete]
+func (a *Album) Delete(c *gin.Context) {
+	log := a.log.WithContext(c).WithField("op", "internal/group/album/Delete")
+
+	id, ok := parseID(c, log, "album")
+	if !ok {
+		return
+	}
+
+	if err := a.albumUsecase.Delete(c, id); err != nil {
+		abortWithError(c, log, err, "Failed to delete album")
+		return
+	}
+
+	log.Infof("Successfully deleted album with ID: %d", id)
+	c.JSON(http.StatusOK, "the album has been deleted")
+}
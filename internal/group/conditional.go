@@ -0,0 +1,68 @@
+package group
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondCacheable writes body as JSON with a weak ETag, Last-Modified and
+// Cache-Control, answering 304 when the client's copy is still current. A
+// zero lastModified omits the header. The ETag is weak because the bytes on
+// the wire depend on the negotiated Content-Encoding, and it stays the same
+// on 200 and 304.
+func respondCacheable(c *gin.Context, body any, lastModified time.Time, cacheControl string) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(data)
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+
+	c.Header("ETag", etag)
+	if cacheControl != "" {
+		c.Header("Cache-Control", cacheControl)
+	}
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(c.Request, etag, lastModified) {
+		c.Status(http.StatusNotModified)
+		return nil
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+	return nil
+}
+
+// notModified evaluates the preconditions as RFC 9110 orders them:
+// If-Modified-Since is ignored when If-None-Match is present, which is
+// compared weakly.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ims)
+		if err != nil {
+			return false
+		}
+		return !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
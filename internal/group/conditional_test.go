@@ -0,0 +1,160 @@
+package group
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNotModified(t *testing.T) {
+	const etag = `W/"abc"`
+	modified := time.Date(2024, 5, 1, 12, 0, 0, 500, time.UTC)
+
+	tests := []struct {
+		name         string
+		headers      map[string]string
+		lastModified time.Time
+		want         bool
+	}{
+		{name: "no preconditions", lastModified: modified, want: false},
+		{name: "matching etag", headers: map[string]string{"If-None-Match": `W/"abc"`}, want: true},
+		{name: "strong form matches weakly", headers: map[string]string{"If-None-Match": `"abc"`}, want: true},
+		{name: "one of several", headers: map[string]string{"If-None-Match": `"x", W/"abc" , "y"`}, want: true},
+		{name: "wildcard", headers: map[string]string{"If-None-Match": "*"}, want: true},
+		{name: "other etag", headers: map[string]string{"If-None-Match": `W/"abd"`}, want: false},
+		{
+			name:         "if-none-match wins over if-modified-since",
+			headers:      map[string]string{"If-None-Match": `W/"other"`, "If-Modified-Since": modified.Add(time.Hour).Format(http.TimeFormat)},
+			lastModified: modified,
+			want:         false,
+		},
+		{
+			name:         "not modified since",
+			headers:      map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)},
+			lastModified: modified,
+			want:         true,
+		},
+		{
+			name:         "modified since",
+			headers:      map[string]string{"If-Modified-Since": modified.Add(-time.Second).Format(http.TimeFormat)},
+			lastModified: modified,
+			want:         false,
+		},
+		{
+			name:    "unknown last modified",
+			headers: map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)},
+			want:    false,
+		},
+		{
+			name:         "unparsable date",
+			headers:      map[string]string{"If-Modified-Since": "yesterday"},
+			lastModified: modified,
+			want:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+
+			if got := notModified(r, etag, tt.lastModified); got != tt.want {
+				t.Errorf("notModified() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRespondCacheable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	body := map[string]string{"song": "Uprising"}
+	modified := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	serve := func(headers map[string]string, lastModified time.Time) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		for k, v := range headers {
+			c.Request.Header.Set(k, v)
+		}
+
+		if err := respondCacheable(c, body, lastModified, "private, max-age=60"); err != nil {
+			t.Fatalf("respondCacheable() error = %v", err)
+		}
+		c.Writer.WriteHeaderNow()
+		return w
+	}
+
+	first := serve(nil, modified)
+	etag := first.Header().Get("ETag")
+	if !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("ETag %q is not weak", etag)
+	}
+
+	tests := []struct {
+		name             string
+		headers          map[string]string
+		lastModified     time.Time
+		wantStatus       int
+		wantLastModified string
+	}{
+		{
+			name:             "fresh request",
+			lastModified:     modified,
+			wantStatus:       http.StatusOK,
+			wantLastModified: "Wed, 01 May 2024 12:00:00 GMT",
+		},
+		{
+			name:             "revalidated by etag",
+			headers:          map[string]string{"If-None-Match": etag},
+			lastModified:     modified,
+			wantStatus:       http.StatusNotModified,
+			wantLastModified: "Wed, 01 May 2024 12:00:00 GMT",
+		},
+		{
+			name:             "revalidated by date",
+			headers:          map[string]string{"If-Modified-Since": "Wed, 01 May 2024 12:00:00 GMT"},
+			lastModified:     modified,
+			wantStatus:       http.StatusNotModified,
+			wantLastModified: "Wed, 01 May 2024 12:00:00 GMT",
+		},
+		{
+			name:       "no last modified",
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(tt.headers, tt.lastModified)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("ETag"); got != etag {
+				t.Errorf("ETag = %q, want %q", got, etag)
+			}
+			if got := w.Header().Get("Last-Modified"); got != tt.wantLastModified {
+				t.Errorf("Last-Modified = %q, want %q", got, tt.wantLastModified)
+			}
+			if got := w.Header().Get("Cache-Control"); got != "private, max-age=60" {
+				t.Errorf("Cache-Control = %q", got)
+			}
+
+			wantBody := `{"song":"Uprising"}`
+			if tt.wantStatus == http.StatusNotModified {
+				wantBody = ""
+			}
+			if w.Body.String() != wantBody {
+				t.Errorf("body = %q, want %q", w.Body.String(), wantBody)
+			}
+		})
+	}
+}
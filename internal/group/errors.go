@@ -0,0 +1,34 @@
+package group
+
+import (
+	"errors"
+	"net/http"
+	"song_lib/internal/domain/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// abortWithError maps a usecase error to a response: validation failures
+// become a 422 listing the offending fields, missing entities a 404,
+// conflicts a 409 and anything else a 500.
+func abortWithError(c *gin.Context, log *logrus.Entry, err error, msg string) {
+	var validationErr *model.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		log.WithError(err).Warn(msg)
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, validationErr)
+		return
+	case errors.Is(err, model.ErrNotFound):
+		log.WithError(err).Warn(msg)
+		c.AbortWithStatusJSON(http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, model.ErrConflict):
+		log.WithError(err).Warn(msg)
+		c.AbortWithStatusJSON(http.StatusConflict, err.Error())
+		return
+	}
+
+	log.WithError(err).Error(msg)
+	c.AbortWithStatusJSON(http.StatusInternalServerError, "something went wrong")
+}
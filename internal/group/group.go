@@ -0,0 +1,251 @@
+package group
+
+import (
+	"net/http"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type Group struct {
+	groupUsecase usecase.Group
+	log          *logrus.Logger
+}
+
+func NewGroup(groupUsecase usecase.Group, log *logrus.Logger) *Group {
+	return &Group{
+		groupUsecase: groupUsecase,
+		log:          log,
+	}
+}
+
+// @Summary List groups
+// @Tags groups
+// @Description Lists groups ordered by name
+// @ID list-groups
+// @Produce json
+// @Param page query int false "Page number" default(0)
+// @Param per_page query int false "Number of groups per page" default(10)
+// @Success 200 {array} model.Group "List of groups"
+// @Failure 400 {string} string "Invalid request format"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/groups [get]
+func (g *Group) List(c *gin.Context) {
+	log := g.log.WithContext(c).WithField("op", "internal/group/group/List")
+
+	page, perPage, ok := parsePagination(c, log)
+	if !ok {
+		return
+	}
+
+	groups, err := g.groupUsecase.List(c, model.GroupsRequest{Page: page, PerPage: perPage})
+	if err != nil {
+		abortWithError(c, log, err, "Failed to list groups")
+		return
+	}
+
+	log.Infof("Successfully fetched %d groups", len(groups))
+	c.JSON(http.StatusOK, groups)
+}
+
+// @Summary Get a group
+// @Tags groups
+// @Description Returns a group by ID
+// @ID get-group
+// @Produce json
+// @Param id path int true "Group ID"
+// @Success 200 {object} model.Group "Group details"
+// @Failure 400 {string} string "Invalid group ID"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 404 {string} string "Group not found"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/groups/{id} [get]
+func (g *Group) Get(c *gin.Context) {
+	log := g.log.WithContext(c).WithField("op", "internal/group/group/Get")
+
+	id, ok := parseID(c, log, "group")
+	if !ok {
+		return
+	}
+
+	group, err := g.groupUsecase.Get(c, id)
+	if err != nil {
+		abortWithError(c, log, err, "Failed to fetch group")
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+// @Summary Add a group
+// @Tags groups
+// @Description Creates a group. Names are unique.
+// @ID add-group
+// @Accept json
+// @Produce json
+// @Param group body model.AddGroup true "Group details"
+// @Param Idempotency-Key header string false "Makes retries of this request safe"
+// @Success 201 {object} model.Group "Created group"
+// @Failure 400 {string} string "Incorrect fields"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 409 {string} string "Group already exists"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/groups [post]
+func (g *Group) Add(c *gin.Context) {
+	log := g.log.WithContext(c).WithField("op", "internal/group/group/Add")
+
+	input := model.AddGroup{}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.WithError(err).Error("Incorrect fields in request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, "incorrect fields")
+		return
+	}
+
+	group, err := g.groupUsecase.Add(c, input)
+	if err != nil {
+		abortWithError(c, log, err, "Failed to add group")
+		return
+	}
+
+	log.Infof("Successfully added group with ID: %d", group.ID)
+	c.JSON(http.StatusCreated, group)
+}
+
+// @Summary Update a group
+// @Tags groups
+// @Description Updates the given fields of a group. Renaming renames it for all its songs.
+// @ID update-group
+// @Accept json
+// @Produce json
+// @Param id path int true "Group ID"
+// @Param group body model.UpdateGroup true "Fields to change"
+// @Param Idempotency-Key header string false "Makes retries of this request safe"
+// @Success 200 {object} model.Group "Updated group"
+// @Failure 400 {string} string "Incorrect fields"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 404 {string} string "Group not found"
+// @Failure 409 {string} string "Group name already taken"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/groups/{id} [put]
+func (g *Group) Update(c *gin.Context) {
+	log := g.log.WithContext(c).WithField("op", "internal/group/group/Update")
+
+	id, ok := parseID(c, log, "group")
+	if !ok {
+		return
+	}
+
+	input := model.UpdateGroup{}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.WithError(err).Error("Incorrect fields in request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, "incorrect fields")
+		return
+	}
+	input.ID = id
+
+	group, err := g.groupUsecase.Update(c, input)
+	if err != nil {
+		abortWithError(c, log, err, "Failed to update group")
+		return
+	}
+
+	log.Infof("Successfully updated group with ID: %d", group.ID)
+	c.JSON(http.StatusOK, group)
+}
+
+// @Summary Delete a group
+// @Tags groups
+// @Description Deletes a group that no song or album refers to
+// @ID delete-group
+// @Produce json
+// @Param id path int true "Group ID"
+// @Success 200 {string} string "The group has been deleted"
+// @Failure 400 {string} string "Invalid group ID"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 404 {string} string "Group not found"
+// @Failure 409 {string} string "Group is still credited on songs or albums"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/groups/{id} [delete]
+func (g *Group) Delete(c *gin.Context) {
+	log := g.log.WithContext(c).WithField("op", "internal/group/group/Delete")
+
+	id, ok := parseID(c, log, "group")
+	if !ok {
+		return
+	}
+
+	if err := g.groupUsecase.Delete(c, id); err != nil {
+		abortWithError(c, log, err, "Failed to delete group")
+		return
+	}
+
+	log.Infof("Successfully deleted group with ID: %d", id)
+	c.JSON(http.StatusOK, "the group has been deleted")
+}
+
+// @Summary List a group's songs
+// @Tags groups
+// @Description Lists the songs crediting a group in any role, ordered by ID
+// @ID group-songs
+// @Produce json
+// @Param id path int true "Group ID"
+// @Param page query int false "Page number" default(0)
+// @Param per_page query int false "Number of songs per page" default(10)
+// @Success 200 {array} model.Song "Songs of the group"
+// @Failure 400 {string} string "Invalid request format"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 404 {string} string "Group not found"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/groups/{id}/songs [get]
+func (g *Group) GetSongs(c *gin.Context) {
+	log := g.log.WithContext(c).WithField("op", "internal/group/group/GetSongs")
+
+	id, ok := parseID(c, log, "group")
+	if !ok {
+		return
+	}
+	page, perPage, ok := parsePagination(c, log)
+	if !ok {
+		return
+	}
+
+	songs, err := g.groupUsecase.GetSongs(c, model.GroupSongsRequest{GroupID: id, Page: page, PerPage: perPage})
+	if err != nil {
+		abortWithError(c, log, err, "Failed to fetch group songs")
+		return
+	}
+
+	log.Infof("Successfully fetched %d songs for group ID: %d", len(songs), id)
+	c.JSON(http.StatusOK, songs)
+}
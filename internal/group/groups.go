@@ -1,6 +1,7 @@
 package group
 
 import (
+	"song_lib/internal/config"
 	"song_lib/internal/usecase"
 
 	"github.com/sirupsen/logrus"
@@ -8,10 +9,24 @@ import (
 
 type Groups struct {
 	Song
+	Group
+	Album
+	Person
+	Tag
+	Relation
+	Health
+	Admin
 }
 
-func NewGroups(usecases *usecase.Usecases, log *logrus.Logger) *Groups {
+func NewGroups(cfg *config.Config, usecases *usecase.Usecases, log *logrus.Logger) *Groups {
 	return &Groups{
-		Song: *NewSong(usecases.Song, log),
+		Song:     *NewSong(usecases.Song, &cfg.Cache, log),
+		Group:    *NewGroup(usecases.Group, log),
+		Album:    *NewAlbum(usecases.Album, log),
+		Person:   *NewPerson(usecases.Person, log),
+		Tag:      *NewTag(usecases.Tag, log),
+		Relation: *NewRelation(usecases.Relation, log),
+		Health:   *NewHealth(usecases.Health, log),
+		Admin:    *NewAdmin(usecases.Maintenance, log),
 	}
 }
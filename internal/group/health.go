@@ -0,0 +1,50 @@
+package group
+
+import (
+	"net/http"
+	"song_lib/internal/domain/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type Health struct {
+	healthUsecase usecase.Health
+	log           *logrus.Logger
+}
+
+func NewHealth(healthUsecase usecase.Health, log *logrus.Logger) *Health {
+	return &Health{
+		healthUsecase: healthUsecase,
+		log:           log,
+	}
+}
+
+// @Summary Liveness probe
+// @Tags health
+// @Description Reports that the process is up and serving HTTP
+// @ID healthz
+// @Produce json
+// @Success 200 {string} string "ok"
+// @Router /healthz [get]
+func (h *Health) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, "ok")
+}
+
+// @Summary Readiness probe
+// @Tags health
+// @Description Checks the database connection and schema version
+// @ID readyz
+// @Produce json
+// @Success 200 {object} model.Readiness "Ready to serve traffic"
+// @Failure 503 {object} model.Readiness "Not ready"
+// @Router /readyz [get]
+func (h *Health) Readiness(c *gin.Context) {
+	readiness := h.healthUsecase.Readiness(c)
+	if !readiness.Ready() {
+		c.JSON(http.StatusServiceUnavailable, readiness)
+		return
+	}
+
+	c.JSON(http.StatusOK, readiness)
+}
@@ -0,0 +1,47 @@
+package group
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// parsePagination reads the page and per_page query parameters, leaving
+// range checks to the usecase. It aborts with 400 and reports false when
+// either is not a number.
+func parsePagination(c *gin.Context, log *logrus.Entry) (page, perPage int, ok bool) {
+	for _, p := range []struct {
+		name string
+		dst  *int
+	}{{"page", &page}, {"per_page", &perPage}} {
+		raw := c.Query(p.name)
+		if raw == "" {
+			continue
+		}
+
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			log.WithError(err).Errorf("Invalid %s parameter", p.name)
+			c.AbortWithStatusJSON(http.StatusBadRequest, "invalid "+p.name+" parameter")
+			return 0, 0, false
+		}
+		*p.dst = v
+	}
+
+	return page, perPage, true
+}
+
+// parseID reads the id path parameter, aborting with 400 when it is not a
+// positive integer.
+func parseID(c *gin.Context, log *logrus.Entry, entity string) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		log.WithError(err).Errorf("Invalid %s ID", entity)
+		c.AbortWithStatusJSON(http.StatusBadRequest, "invalid "+entity+" ID")
+		return 0, false
+	}
+
+	return id, true
+}
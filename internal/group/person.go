@@ -0,0 +1,336 @@
+package group
+
+import (
+	"net/http"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type Person struct {
+	personUsecase usecase.Person
+	log           *logrus.Logger
+}
+
+func NewPerson(personUsecase usecase.Person, log *logrus.Logger) *Person {
+	return &Person{
+		personUsecase: personUsecase,
+		log:           log,
+	}
+}
+
+// @Summary List people
+// @Tags people
+// @Description Lists songwriters, composers, producers and arrangers ordered by name
+// @ID list-people
+// @Produce json
+// @Param page query int false "Page number" default(0)
+// @Param per_page query int false "Number of people per page" default(10)
+// @Success 200 {array} model.Person "List of people"
+// @Failure 400 {string} string "Invalid request format"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/people [get]
+func (p *Person) List(c *gin.Context) {
+	log := p.log.WithContext(c).WithField("op", "internal/group/person/List")
+
+	page, perPage, ok := parsePagination(c, log)
+	if !ok {
+		return
+	}
+
+	people, err := p.personUsecase.List(c, model.PeopleRequest{Page: page, PerPage: perPage})
+	if err != nil {
+		abortWithError(c, log, err, "Failed to list people")
+		return
+	}
+
+	log.Infof("Successfully fetched %d people", len(people))
+	c.JSON(http.StatusOK, people)
+}
+
+// @Summary Get a person
+// @Tags people
+// @Description Returns a person by ID
+// @ID get-person
+// @Produce json
+// @Param id path int true "Person ID"
+// @Success 200 {object} model.Person "Person details"
+// @Failure 400 {string} string "Invalid person ID"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 404 {string} string "Person not found"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/people/{id} [get]
+func (p *Person) Get(c *gin.Context) {
+	log := p.log.WithContext(c).WithField("op", "internal/group/person/Get")
+
+	id, ok := parseID(c, log, "person")
+	if !ok {
+		return
+	}
+
+	person, err := p.personUsecase.Get(c, id)
+	if err != nil {
+		abortWithError(c, log, err, "Failed to fetch person")
+		return
+	}
+
+	c.JSON(http.StatusOK, person)
+}
+
+// @Summary Add a person
+// @Tags people
+// @Description Creates a person. Names are unique.
+// @ID add-person
+// @Accept json
+// @Produce json
+// @Param person body model.AddPerson true "Person details"
+// @Param Idempotency-Key header string false "Makes retries of this request safe"
+// @Success 201 {object} model.Person "Created person"
+// @Failure 400 {string} string "Incorrect fields"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 409 {string} string "Person already exists"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/people [post]
+func (p *Person) Add(c *gin.Context) {
+	log := p.log.WithContext(c).WithField("op", "internal/group/person/Add")
+
+	input := model.AddPerson{}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.WithError(err).Error("Incorrect fields in request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, "incorrect fields")
+		return
+	}
+
+	person, err := p.personUsecase.Add(c, input)
+	if err != nil {
+		abortWithError(c, log, err, "Failed to add person")
+		return
+	}
+
+	log.Infof("Successfully added person with ID: %d", person.ID)
+	c.JSON(http.StatusCreated, person)
+}
+
+// @Summary Rename a person
+// @Tags people
+// @Description Renames a person
+// @ID update-person
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param person body model.UpdatePerson true "New name"
+// @Param Idempotency-Key header string false "Makes retries of this request safe"
+// @Success 200 {object} model.Person "Updated person"
+// @Failure 400 {string} string "Incorrect fields"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 404 {string} string "Person not found"
+// @Failure 409 {string} string "Name already taken"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/people/{id} [put]
+func (p *Person) Update(c *gin.Context) {
+	log := p.log.WithContext(c).WithField("op", "internal/group/person/Update")
+
+	id, ok := parseID(c, log, "person")
+	if !ok {
+		return
+	}
+
+	input := model.UpdatePerson{}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.WithError(err).Error("Incorrect fields in request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, "incorrect fields")
+		return
+	}
+	input.ID = id
+
+	person, err := p.personUsecase.Update(c, input)
+	if err != nil {
+		abortWithError(c, log, err, "Failed to update person")
+		return
+	}
+
+	log.Infof("Successfully updated person with ID: %d", person.ID)
+	c.JSON(http.StatusOK, person)
+}
+
+// @Summary Delete a person
+// @Tags people
+// @Description Deletes a person who is no longer credited on any song
+// @ID delete-person
+// @Produce json
+// @Param id path int true "Person ID"
+// @Success 200 {string} string "The person has been deleted"
+// @Failure 400 {string} string "Invalid person ID"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 404 {string} string "Person not found"
+// @Failure 409 {string} string "Person is still credited on songs"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/people/{id} [delete]
+func (p *Person) Delete(c *gin.Context) {
+	log := p.log.WithContext(c).WithField("op", "internal/group/person/Delete")
+
+	id, ok := parseID(c, log, "person")
+	if !ok {
+		return
+	}
+
+	if err := p.personUsecase.Delete(c, id); err != nil {
+		abortWithError(c, log, err, "Failed to delete person")
+		return
+	}
+
+	log.Infof("Successfully deleted person with ID: %d", id)
+	c.JSON(http.StatusOK, "the person has been deleted")
+}
+
+// @Summary List a person's songs
+// @Tags people
+// @Description Lists the songs crediting a person, optionally only in one role, ordered by ID
+// @ID person-songs
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param role query string false "Credit role" Enums(lyricist, composer, producer, arranger)
+// @Param page query int false "Page number" default(0)
+// @Param per_page query int false "Number of songs per page" default(10)
+// @Success 200 {array} model.Song "Songs crediting the person"
+// @Failure 400 {string} string "Invalid request format"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 404 {string} string "Person not found"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/people/{id}/songs [get]
+func (p *Person) GetSongs(c *gin.Context) {
+	log := p.log.WithContext(c).WithField("op", "internal/group/person/GetSongs")
+
+	id, ok := parseID(c, log, "person")
+	if !ok {
+		return
+	}
+	page, perPage, ok := parsePagination(c, log)
+	if !ok {
+		return
+	}
+
+	songs, err := p.personUsecase.GetSongs(c, model.PersonSongsRequest{
+		PersonID: id,
+		Role:     c.Query("role"),
+		Page:     page,
+		PerPage:  perPage,
+	})
+	if err != nil {
+		abortWithError(c, log, err, "Failed to fetch person songs")
+		return
+	}
+
+	log.Infof("Successfully fetched %d songs for person ID: %d", len(songs), id)
+	c.JSON(http.StatusOK, songs)
+}
+
+// @Summary Get song credits
+// @Tags songs
+// @Description Lists the people credited on a song and their roles
+// @ID get-song-credits
+// @Produce json
+// @Param id path int true "Song ID"
+// @Success 200 {array} model.PersonCredit "Credits of the song"
+// @Failure 400 {string} string "Invalid song ID"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 404 {string} string "Song not found"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/songs/{id}/credits [get]
+func (p *Person) GetCredits(c *gin.Context) {
+	log := p.log.WithContext(c).WithField("op", "internal/group/person/GetCredits")
+
+	id, ok := parseID(c, log, "song")
+	if !ok {
+		return
+	}
+
+	credits, err := p.personUsecase.GetCredits(c, id)
+	if err != nil {
+		abortWithError(c, log, err, "Failed to fetch song credits")
+		return
+	}
+
+	c.JSON(http.StatusOK, credits)
+}
+
+// @Summary Replace song credits
+// @Tags songs
+// @Description Replaces all people credited on a song. People named for the first time are created.
+// @ID set-song-credits
+// @Accept json
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param credits body model.SetSongCredits true "People and their roles"
+// @Param Idempotency-Key header string false "Makes retries of this request safe"
+// @Success 200 {array} model.PersonCredit "New credits of the song"
+// @Failure 400 {string} string "Incorrect fields"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 404 {string} string "Song not found"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/songs/{id}/credits [put]
+func (p *Person) SetCredits(c *gin.Context) {
+	log := p.log.WithContext(c).WithField("op", "internal/group/person/SetCredits")
+
+	id, ok := parseID(c, log, "song")
+	if !ok {
+		return
+	}
+
+	input := model.SetSongCredits{}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.WithError(err).Error("Incorrect fields in request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, "incorrect fields")
+		return
+	}
+	input.SongID = id
+
+	credits, err := p.personUsecase.SetCredits(c, input)
+	if err != nil {
+		abortWithError(c, log, err, "Failed to set song credits")
+		return
+	}
+
+	log.Infof("Successfully set %d credits on song ID: %d", len(credits), id)
+	c.JSON(http.StatusOK, credits)
+}
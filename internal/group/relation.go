@@ -0,0 +1,168 @@
+package group
+
+import (
+	"net/http"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/usecase"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type Relation struct {
+	relationUsecase usecase.Relation
+	log             *logrus.Logger
+}
+
+func NewRelation(relationUsecase usecase.Relation, log *logrus.Logger) *Relation {
+	return &Relation{
+		relationUsecase: relationUsecase,
+		log:             log,
+	}
+}
+
+// @Summary Traverse song relations
+// @Tags relations
+// @Description Lists songs related to a song. Outgoing relations lead to the songs it derives from, incoming ones to songs derived from it.
+// @Description A depth above 1 follows chains, such as a cover of a cover.
+// @ID list-song-relations
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param type query string false "Relation type" Enums(cover_of, remix_of, live_version_of, translation_of)
+// @Param direction query string false "Relation direction, both when omitted" Enums(outgoing, incoming)
+// @Param depth query int false "Number of relations to follow" default(1)
+// @Success 200 {array} model.SongRelation "Related songs"
+// @Failure 400 {string} string "Invalid request format"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 404 {string} string "Song not found"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/songs/{id}/relations [get]
+func (r *Relation) List(c *gin.Context) {
+	log := r.log.WithContext(c).WithField("op", "internal/group/relation/List")
+
+	id, ok := parseID(c, log, "song")
+	if !ok {
+		return
+	}
+
+	depth := 0
+	if depthStr := c.Query("depth"); depthStr != "" {
+		var err error
+		depth, err = strconv.Atoi(depthStr)
+		if err != nil {
+			log.WithError(err).Error("Invalid depth parameter")
+			c.AbortWithStatusJSON(http.StatusBadRequest, "invalid depth parameter")
+			return
+		}
+	}
+
+	relations, err := r.relationUsecase.List(c, model.SongRelationsRequest{
+		SongID:    id,
+		Type:      c.Query("type"),
+		Direction: c.Query("direction"),
+		Depth:     depth,
+	})
+	if err != nil {
+		abortWithError(c, log, err, "Failed to fetch song relations")
+		return
+	}
+
+	log.Infof("Successfully fetched %d relations for song ID: %d", len(relations), id)
+	c.JSON(http.StatusOK, relations)
+}
+
+// @Summary Relate two songs
+// @Tags relations
+// @Description Records that the song is a cover, remix, live version or translation of another song
+// @ID add-song-relation
+// @Accept json
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param relation body model.AddSongRelation true "Relation type and the song it points to"
+// @Param Idempotency-Key header string false "Makes retries of this request safe"
+// @Success 201 {object} model.SongRelation "Created relation"
+// @Failure 400 {string} string "Incorrect fields"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 404 {string} string "Song not found"
+// @Failure 409 {string} string "Relation already exists"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/songs/{id}/relations [post]
+func (r *Relation) Add(c *gin.Context) {
+	log := r.log.WithContext(c).WithField("op", "internal/group/relation/Add")
+
+	id, ok := parseID(c, log, "song")
+	if !ok {
+		return
+	}
+
+	input := model.AddSongRelation{}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.WithError(err).Error("Incorrect fields in request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, "incorrect fields")
+		return
+	}
+	input.SongID = id
+
+	relation, err := r.relationUsecase.Add(c, input)
+	if err != nil {
+		abortWithError(c, log, err, "Failed to add song relation")
+		return
+	}
+
+	c.JSON(http.StatusCreated, relation)
+}
+
+// @Summary Remove a song relation
+// @Tags relations
+// @Description Removes a relation from the song to another song
+// @ID delete-song-relation
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param type path string true "Relation type" Enums(cover_of, remix_of, live_version_of, translation_of)
+// @Param relatedId path int true "ID of the song the relation points to"
+// @Success 200 {string} string "The relation has been deleted"
+// @Failure 400 {string} string "Invalid song ID"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 404 {string} string "Relation not found"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/songs/{id}/relations/{type}/{relatedId} [delete]
+func (r *Relation) Delete(c *gin.Context) {
+	log := r.log.WithContext(c).WithField("op", "internal/group/relation/Delete")
+
+	id, ok := parseID(c, log, "song")
+	if !ok {
+		return
+	}
+
+	relatedID, err := strconv.ParseUint(c.Param("relatedId"), 10, 64)
+	if err != nil {
+		log.WithError(err).Error("Invalid related song ID")
+		c.AbortWithStatusJSON(http.StatusBadRequest, "invalid related song ID")
+		return
+	}
+
+	request := model.DeleteSongRelation{SongID: id, Type: c.Param("type"), RelatedSongID: relatedID}
+	if err := r.relationUsecase.Delete(c, request); err != nil {
+		abortWithError(c, log, err, "Failed to delete song relation")
+		return
+	}
+
+	c.JSON(http.StatusOK, "the relation has been deleted")
+}
@@ -2,9 +2,12 @@ package group
 
 import (
 	"net/http"
+	"song_lib/internal/config"
 	"song_lib/internal/domain/model"
 	"song_lib/internal/domain/usecase"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
@@ -12,12 +15,14 @@ import (
 
 type Song struct {
 	songUsecase usecase.Song
+	cache       *config.Cache
 	log         *logrus.Logger
 }
 
-func NewSong(songUsecases usecase.Song, log *logrus.Logger) *Song {
+func NewSong(songUsecases usecase.Song, cache *config.Cache, log *logrus.Logger) *Song {
 	return &Song{
 		songUsecase: songUsecases,
+		cache:       cache,
 		log:         log,
 	}
 }
@@ -29,14 +34,28 @@ func NewSong(songUsecases usecase.Song, log *logrus.Logger) *Song {
 // @Produce json
 // @Param page query int false "Page number" default(0)
 // @Param per_page query int false "Number of songs per page" default(10)
-// @Param group query string false "Group name"
+// @Param group query string false "Name of any credited group"
 // @Param song query string false "Song title"
+// @Param album query string false "Album title"
+// @Param lyricist query string false "Name of a credited lyricist"
+// @Param composer query string false "Name of a credited composer"
+// @Param producer query string false "Name of a credited producer"
+// @Param arranger query string false "Name of a credited arranger"
+// @Param tags query string false "Comma-separated tags, e.g. rock,90s"
+// @Param tags_match query string false "Whether songs need all or any of the tags" Enums(all, any) default(all)
+// @Param If-None-Match header string false "ETag of the cached copy"
 // @Success 200 {array} []model.SongDetails "List of songs"
+// @Success 304 "Not modified"
 // @Failure 400 {string} string "Invalid request format"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
 // @Failure 500 {string} string "Server error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/songs/info [get]
 func (s *Song) GetLib(c *gin.Context) {
-	log := s.log.WithField("op", "internal/group/song/GetLib")
+	log := s.log.WithContext(c).WithField("op", "internal/group/song/GetLib")
 
 	pageStr := c.Query("page")
 	perPageStr := c.Query("per_page")
@@ -68,25 +87,79 @@ func (s *Song) GetLib(c *gin.Context) {
 
 	group := c.Query("group")
 	song := c.Query("song")
+	album := c.Query("album")
+
+	var tags []string
+	if tagsStr := c.Query("tags"); tagsStr != "" {
+		tags = strings.Split(tagsStr, ",")
+	}
 
 	input := model.LibraryFilter{
-		Page:    page,
-		PerPage: perPage,
-		Group:   group,
-		Song:    song,
+		Page:      page,
+		PerPage:   perPage,
+		Group:     group,
+		Song:      song,
+		Album:     album,
+		Lyricist:  c.Query("lyricist"),
+		Composer:  c.Query("composer"),
+		Producer:  c.Query("producer"),
+		Arranger:  c.Query("arranger"),
+		Tags:      tags,
+		TagsMatch: c.Query("tags_match"),
 	}
 
 	log.Infof("Fetching library with input: %+v", input)
 
 	songs, err := s.songUsecase.GetLib(c, input)
 	if err != nil {
-		log.WithError(err).Error("Failed to fetch library")
-		c.AbortWithStatusJSON(http.StatusInternalServerError, "something went wrong")
+		abortWithError(c, log, err, "Failed to fetch library")
 		return
 	}
 
-	log.Infof("Successfully fetched songs: %+v", songs)
-	c.JSON(http.StatusOK, songs)
+	// A page can lose songs to deletes without any remaining song changing,
+	// so only the ETag tracks list freshness.
+	log.Infof("Successfully fetched %d songs", len(songs))
+	if err := respondCacheable(c, songs, time.Time{}, s.cache.SongsInfo); err != nil {
+		abortWithError(c, log, err, "Failed to encode songs")
+	}
+}
+
+// @Summary Get a song
+// @Tags songs
+// @Description Returns a song with its credited artists and the songs it is directly related to
+// @ID get-song
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param If-None-Match header string false "ETag of the cached copy"
+// @Success 200 {object} model.Song "Song details"
+// @Success 304 "Not modified"
+// @Failure 400 {string} string "Invalid song ID"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 404 {string} string "Song not found"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/songs/{id} [get]
+func (s *Song) Get(c *gin.Context) {
+	log := s.log.WithContext(c).WithField("op", "internal/group/song/Get")
+
+	id, ok := parseID(c, log, "song")
+	if !ok {
+		return
+	}
+
+	song, err := s.songUsecase.Get(c, id)
+	if err != nil {
+		abortWithError(c, log, err, "Failed to fetch song")
+		return
+	}
+
+	// Credit and relation changes don't touch the song's updated_at, so only
+	// the ETag tracks its freshness.
+	if err := respondCacheable(c, song, time.Time{}, s.cache.Song); err != nil {
+		abortWithError(c, log, err, "Failed to encode song")
+	}
 }
 
 // @Summary Get song verses
@@ -97,13 +170,21 @@ func (s *Song) GetLib(c *gin.Context) {
 // @Param id path int true "Song ID"
 // @Param page query int false "Page number" default(0)
 // @Param per_page query int false "Number of verses per page" default(10)
+// @Param If-None-Match header string false "ETag of the cached copy"
+// @Param If-Modified-Since header string false "Last-Modified of the cached copy"
 // @Success 200 {array} model.VersesResponse "List of song verses"
+// @Success 304 "Not modified"
 // @Failure 400 {string} string "Invalid request format"
 // @Failure 404 {string} string "Song not found"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
 // @Failure 500 {string} string "Server error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/songs/{id}/verses [get]
 func (s *Song) GetVerses(c *gin.Context) {
-	log := s.log.WithField("op", "internal/group/song/GetVerses")
+	log := s.log.WithContext(c).WithField("op", "internal/group/song/GetVerses")
 
 	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
@@ -148,13 +229,59 @@ func (s *Song) GetVerses(c *gin.Context) {
 
 	verses, err := s.songUsecase.GetVerses(c, input)
 	if err != nil {
-		log.WithError(err).Error("Failed to fetch verses")
-		c.AbortWithStatusJSON(http.StatusInternalServerError, "something went wrong")
+		abortWithError(c, log, err, "Failed to fetch verses")
 		return
 	}
 
-	log.Infof("Successfully fetched verses: %+v", verses)
-	c.JSON(http.StatusOK, verses)
+	log.Infof("Successfully fetched %d verses", len(verses.Verses))
+	if err := respondCacheable(c, verses, verses.UpdatedAt, s.cache.SongsVerses); err != nil {
+		abortWithError(c, log, err, "Failed to encode verses")
+	}
+}
+
+// @Summary Get library changes
+// @Tags songs
+// @Description Get songs created, updated or deleted since a sync token, for incremental offline sync. Omit since for a full sync and keep calling with nextToken while hasMore is true.
+// @ID get-song-changes
+// @Produce json
+// @Param since query string false "Sync token from a previous response"
+// @Param limit query int false "Maximum number of changes" default(100)
+// @Success 200 {object} model.SongChanges "Changes since the token"
+// @Failure 400 {string} string "Invalid request format"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/songs/changes [get]
+func (s *Song) GetChanges(c *gin.Context) {
+	log := s.log.WithContext(c).WithField("op", "internal/group/song/GetChanges")
+
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			log.WithError(err).Error("Invalid limit parameter")
+			c.AbortWithStatusJSON(http.StatusBadRequest, "invalid limit parameter")
+			return
+		}
+	}
+
+	input := model.ChangesRequest{
+		Since: c.Query("since"),
+		Limit: limit,
+	}
+
+	changes, err := s.songUsecase.GetChanges(c, input)
+	if err != nil {
+		abortWithError(c, log, err, "Failed to fetch changes")
+		return
+	}
+
+	log.Infof("Successfully fetched %d upserts and %d deletions", len(changes.Upserts), len(changes.Deletions))
+	c.JSON(http.StatusOK, changes)
 }
 
 // @Summary Add a new song
@@ -163,12 +290,21 @@ func (s *Song) GetVerses(c *gin.Context) {
 // @ID add-song
 // @Produce json
 // @Param song body model.AddSong true "Song details"
+// @Param Idempotency-Key header string false "Makes retries of this request safe"
 // @Success 200 {integer} int "ID of the created song"
 // @Failure 400 {string} string "Incorrect fields"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 409 {string} string "Request with this idempotency key is in progress"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
 // @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/songs [post]
 func (s *Song) Add(c *gin.Context) {
-	log := s.log.WithField("op", "internal/group/song/Add")
+	log := s.log.WithContext(c).WithField("op", "internal/group/song/Add")
 
 	input := model.AddSong{}
 
@@ -180,8 +316,7 @@ func (s *Song) Add(c *gin.Context) {
 
 	id, err := s.songUsecase.Add(c, input)
 	if err != nil {
-		log.WithError(err).Error("Failed to add song")
-		c.JSON(http.StatusInternalServerError, "something went wrong")
+		abortWithError(c, log, err, "Failed to add song")
 		return
 	}
 
@@ -196,13 +331,22 @@ func (s *Song) Add(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Song ID"
 // @Param song body model.UpdateSongSwagger true "Updated song details"
+// @Param Idempotency-Key header string false "Makes retries of this request safe"
 // @Success 200 {object} model.Song "Updated song details"
 // @Failure 400 {string} string "Incorrect fields"
 // @Failure 404 {string} string "Song not found"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 409 {string} string "Request with this idempotency key is in progress"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
 // @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/songs/{id} [put]
 func (s *Song) Update(c *gin.Context) {
-	log := s.log.WithField("op", "internal/group/song/Update")
+	log := s.log.WithContext(c).WithField("op", "internal/group/song/Update")
 
 	input := model.UpdateSong{}
 
@@ -223,12 +367,11 @@ func (s *Song) Update(c *gin.Context) {
 
 	song, err := s.songUsecase.Update(c, input)
 	if err != nil {
-		log.WithError(err).Error("Failed to update song")
-		c.AbortWithStatusJSON(http.StatusInternalServerError, "something went wrong")
+		abortWithError(c, log, err, "Failed to update song")
 		return
 	}
 
-	log.Infof("Successfully updated song: %+v", song)
+	log.Infof("Successfully updated song with ID: %d", song.ID)
 	c.JSON(http.StatusOK, song)
 }
 
@@ -241,10 +384,16 @@ func (s *Song) Update(c *gin.Context) {
 // @Success 200 {string} string "The song has been deleted"
 // @Failure 400 {string} string "Invalid song ID or something went wrong"
 // @Failure 404 {string} string "Song not found"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 429 {string} string "Rate limit exceeded"
 // @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/songs/{id} [delete]
 func (s *Song) Delete(c *gin.Context) {
-	log := s.log.WithField("op", "internal/group/song/Delete")
+	log := s.log.WithContext(c).WithField("op", "internal/group/song/Delete")
 
 	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
@@ -255,11 +404,59 @@ func (s *Song) Delete(c *gin.Context) {
 
 	err = s.songUsecase.Delete(c, id)
 	if err != nil {
-		log.WithError(err).Error("Failed to delete song")
-		c.AbortWithStatusJSON(http.StatusInternalServerError, "something went wrong")
+		abortWithError(c, log, err, "Failed to delete song")
 		return
 	}
 
 	log.Infof("Successfully deleted song with ID: %d", id)
 	c.JSON(http.StatusOK, "the song has been deleted")
 }
+
+// @Summary Bulk update or delete songs
+// @Tags songs
+// @Description Applies delete, set-field or rename-group to every song matching the filter in one transaction.
+// @Description Operations matching more songs than the configured limit are refused unless forced. Dry runs are never refused.
+// @ID bulk-songs
+// @Accept json
+// @Produce json
+// @Param request body model.BulkRequest true "Operation and filter"
+// @Param dry_run query bool false "Only report the matching songs"
+// @Param force query bool false "Allow exceeding the affected songs limit"
+// @Param Idempotency-Key header string false "Makes retries of this request safe"
+// @Success 200 {object} model.BulkResult "Matching ids and counts"
+// @Failure 400 {string} string "Incorrect fields"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 409 {string} string "Request with this idempotency key is in progress"
+// @Failure 422 {object} model.ValidationError "Invalid field values or too many songs matched"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/songs/bulk [post]
+func (s *Song) Bulk(c *gin.Context) {
+	log := s.log.WithContext(c).WithField("op", "internal/group/song/Bulk")
+
+	input := model.BulkRequest{}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.WithError(err).Error("Incorrect fields in request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, "incorrect fields")
+		return
+	}
+	if err := c.ShouldBindQuery(&input); err != nil {
+		log.WithError(err).Error("Invalid query parameters")
+		c.AbortWithStatusJSON(http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+
+	result, err := s.songUsecase.Bulk(c, input)
+	if err != nil {
+		abortWithError(c, log, err, "Failed to run bulk operation")
+		return
+	}
+
+	log.Infof("Bulk %s matched %d songs and changed %d", result.Operation, result.Matched, result.Affected)
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,170 @@
+package group
+
+import (
+	"net/http"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type Tag struct {
+	tagUsecase usecase.Tag
+	log        *logrus.Logger
+}
+
+func NewTag(tagUsecase usecase.Tag, log *logrus.Logger) *Tag {
+	return &Tag{
+		tagUsecase: tagUsecase,
+		log:        log,
+	}
+}
+
+// @Summary List tags
+// @Tags tags
+// @Description Lists tags with the number of songs carrying each, most used first
+// @ID list-tags
+// @Produce json
+// @Param page query int false "Page number" default(0)
+// @Param per_page query int false "Number of tags per page" default(10)
+// @Success 200 {array} model.TagCount "Tags with song counts"
+// @Failure 400 {string} string "Invalid request format"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/tags [get]
+func (t *Tag) List(c *gin.Context) {
+	log := t.log.WithContext(c).WithField("op", "internal/group/tag/List")
+
+	page, perPage, ok := parsePagination(c, log)
+	if !ok {
+		return
+	}
+
+	tags, err := t.tagUsecase.List(c, model.TagsRequest{Page: page, PerPage: perPage})
+	if err != nil {
+		abortWithError(c, log, err, "Failed to list tags")
+		return
+	}
+
+	log.Infof("Successfully fetched %d tags", len(tags))
+	c.JSON(http.StatusOK, tags)
+}
+
+// @Summary Get song tags
+// @Tags tags
+// @Description Lists the tags of a song
+// @ID get-song-tags
+// @Produce json
+// @Param id path int true "Song ID"
+// @Success 200 {object} model.SongTags "Tags of the song"
+// @Failure 400 {string} string "Invalid song ID"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 404 {string} string "Song not found"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/songs/{id}/tags [get]
+func (t *Tag) GetSongTags(c *gin.Context) {
+	log := t.log.WithContext(c).WithField("op", "internal/group/tag/GetSongTags")
+
+	id, ok := parseID(c, log, "song")
+	if !ok {
+		return
+	}
+
+	tags, err := t.tagUsecase.GetSongTags(c, id)
+	if err != nil {
+		abortWithError(c, log, err, "Failed to fetch song tags")
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+// @Summary Attach tags to a song
+// @Tags tags
+// @Description Adds tags to a song. Tags are lowercased and created when used for the first time; tags the song already carries are ignored.
+// @ID attach-song-tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param tags body model.AttachTags true "Tags to add"
+// @Param Idempotency-Key header string false "Makes retries of this request safe"
+// @Success 200 {object} model.SongTags "All tags of the song"
+// @Failure 400 {string} string "Incorrect fields"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 404 {string} string "Song not found"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/songs/{id}/tags [post]
+func (t *Tag) Attach(c *gin.Context) {
+	log := t.log.WithContext(c).WithField("op", "internal/group/tag/Attach")
+
+	id, ok := parseID(c, log, "song")
+	if !ok {
+		return
+	}
+
+	input := model.AttachTags{}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.WithError(err).Error("Incorrect fields in request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, "incorrect fields")
+		return
+	}
+	input.SongID = id
+
+	tags, err := t.tagUsecase.Attach(c, input)
+	if err != nil {
+		abortWithError(c, log, err, "Failed to attach tags")
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+// @Summary Detach a tag from a song
+// @Tags tags
+// @Description Removes a tag from a song. Removing a tag the song does not carry succeeds.
+// @ID detach-song-tag
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param tag path string true "Tag name"
+// @Success 200 {object} model.SongTags "Remaining tags of the song"
+// @Failure 400 {string} string "Invalid song ID"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Insufficient permissions"
+// @Failure 404 {string} string "Song not found"
+// @Failure 422 {object} model.ValidationError "Invalid field values"
+// @Failure 429 {string} string "Rate limit exceeded"
+// @Failure 500 {string} string "Server error"
+// @Failure 503 {string} string "Read-only maintenance mode"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/songs/{id}/tags/{tag} [delete]
+func (t *Tag) Detach(c *gin.Context) {
+	log := t.log.WithContext(c).WithField("op", "internal/group/tag/Detach")
+
+	id, ok := parseID(c, log, "song")
+	if !ok {
+		return
+	}
+
+	tags, err := t.tagUsecase.Detach(c, model.DetachTag{SongID: id, Tag: c.Param("tag")})
+	if err != nil {
+		abortWithError(c, log, err, "Failed to detach tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
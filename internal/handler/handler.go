@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"net/http"
+	"net/http/pprof"
+	"song_lib/internal/domain/model"
 	"song_lib/internal/group"
+	"song_lib/internal/middleware"
 
 	_ "song_lib/docs"
 
@@ -15,17 +19,105 @@ import (
 // @description     This is an API server for working with songs
 //
 //	@host			localhost:8080
-func InitRoutes(router *gin.Engine, groups group.Groups) {
-	api := router.Group("/api/v1")
+//
+//	@securityDefinitions.apikey	ApiKeyAuth
+//	@in							header
+//	@name						X-API-Key
+//
+//	@securityDefinitions.apikey	BearerAuth
+//	@in							header
+//	@name						Authorization
+func InitRoutes(router *gin.Engine, groups group.Groups, middlewares middleware.Middlewares) {
+	api := router.Group("/api/v1",
+		middlewares.Compression.Compress(),
+		middlewares.CORS.Handle(),
+		middlewares.Auth.Authenticate(),
+		middlewares.RateLimit.Limit(),
+		middlewares.Maintenance.ReadOnly(),
+	)
 	{
+		api.OPTIONS("/*path", middlewares.CORS.Preflight)
+
 		songs := api.Group("/songs")
 		{
-			songs.GET("/info", groups.Song.GetLib)
-			songs.GET("/:id/verses", groups.Song.GetVerses)
-			songs.POST("/", groups.Song.Add)
-			songs.PUT("/:id", groups.Song.Update)
-			songs.DELETE("/:id", groups.Song.Delete)
+			songs.GET("/info", middlewares.Auth.Require(model.RoleReader), groups.Song.GetLib)
+			songs.GET("/:id", middlewares.Auth.Require(model.RoleReader), groups.Song.Get)
+			songs.GET("/:id/verses", middlewares.Auth.Require(model.RoleReader), groups.Song.GetVerses)
+			songs.GET("/changes", middlewares.Auth.Require(model.RoleReader), groups.Song.GetChanges)
+			songs.POST("/", middlewares.Auth.Require(model.RoleEditor), middlewares.Idempotency.Handle(), groups.Song.Add)
+			songs.PUT("/:id", middlewares.Auth.Require(model.RoleEditor), middlewares.Idempotency.Handle(), groups.Song.Update)
+			songs.DELETE("/:id", middlewares.Auth.Require(model.RoleAdmin), groups.Song.Delete)
+			songs.POST("/bulk", middlewares.Auth.Require(model.RoleAdmin), middlewares.Idempotency.Handle(), groups.Song.Bulk)
+			songs.GET("/:id/credits", middlewares.Auth.Require(model.RoleReader), groups.Person.GetCredits)
+			songs.PUT("/:id/credits", middlewares.Auth.Require(model.RoleEditor), middlewares.Idempotency.Handle(), groups.Person.SetCredits)
+			songs.GET("/:id/tags", middlewares.Auth.Require(model.RoleReader), groups.Tag.GetSongTags)
+			songs.POST("/:id/tags", middlewares.Auth.Require(model.RoleEditor), middlewares.Idempotency.Handle(), groups.Tag.Attach)
+			songs.DELETE("/:id/tags/:tag", middlewares.Auth.Require(model.RoleEditor), groups.Tag.Detach)
+			songs.GET("/:id/relations", middlewares.Auth.Require(model.RoleReader), groups.Relation.List)
+			songs.POST("/:id/relations", middlewares.Auth.Require(model.RoleEditor), middlewares.Idempotency.Handle(), groups.Relation.Add)
+			songs.DELETE("/:id/relations/:type/:relatedId", middlewares.Auth.Require(model.RoleEditor), groups.Relation.Delete)
+		}
+
+		groupRoutes := api.Group("/groups")
+		{
+			groupRoutes.GET("/", middlewares.Auth.Require(model.RoleReader), groups.Group.List)
+			groupRoutes.GET("/:id", middlewares.Auth.Require(model.RoleReader), groups.Group.Get)
+			groupRoutes.GET("/:id/songs", middlewares.Auth.Require(model.RoleReader), groups.Group.GetSongs)
+			groupRoutes.POST("/", middlewares.Auth.Require(model.RoleEditor), middlewares.Idempotency.Handle(), groups.Group.Add)
+			groupRoutes.PUT("/:id", middlewares.Auth.Require(model.RoleEditor), middlewares.Idempotency.Handle(), groups.Group.Update)
+			groupRoutes.DELETE("/:id", middlewares.Auth.Require(model.RoleAdmin), groups.Group.Delete)
+		}
+
+		albums := api.Group("/albums")
+		{
+			albums.GET("/", middlewares.Auth.Require(model.RoleReader), groups.Album.List)
+			albums.GET("/:id", middlewares.Auth.Require(model.RoleReader), groups.Album.Get)
+			albums.POST("/", middlewares.Auth.Require(model.RoleEditor), middlewares.Idempotency.Handle(), groups.Album.Add)
+			albums.PUT("/:id", middlewares.Auth.Require(model.RoleEditor), middlewares.Idempotency.Handle(), groups.Album.Update)
+			albums.PUT("/:id/tracks", middlewares.Auth.Require(model.RoleEditor), middlewares.Idempotency.Handle(), groups.Album.SetTracks)
+			albums.DELETE("/:id", middlewares.Auth.Require(model.RoleAdmin), groups.Album.Delete)
+		}
+
+		people := api.Group("/people")
+		{
+			people.GET("/", middlewares.Auth.Require(model.RoleReader), groups.Person.List)
+			people.GET("/:id", middlewares.Auth.Require(model.RoleReader), groups.Person.Get)
+			people.GET("/:id/songs", middlewares.Auth.Require(model.RoleReader), groups.Person.GetSongs)
+			people.POST("/", middlewares.Auth.Require(model.RoleEditor), middlewares.Idempotency.Handle(), groups.Person.Add)
+			people.PUT("/:id", middlewares.Auth.Require(model.RoleEditor), middlewares.Idempotency.Handle(), groups.Person.Update)
+			people.DELETE("/:id", middlewares.Auth.Require(model.RoleAdmin), groups.Person.Delete)
 		}
+
+		api.GET("/tags", middlewares.Auth.Require(model.RoleReader), groups.Tag.List)
 	}
+}
+
+// InitAdminRoutes registers the operational endpoints served on the admin
+// listener, keeping them off the public port. Changing state and profiling
+// take an admin credential, so a listener bound too widely doesn't hand them
+// out.
+func InitAdminRoutes(router *gin.Engine, groups group.Groups, middlewares middleware.Middlewares, metrics http.Handler, enablePprof bool) {
+	router.GET("/healthz", groups.Health.Liveness)
+	router.GET("/readyz", groups.Health.Readiness)
+	router.GET("/metrics", gin.WrapH(metrics))
+	router.GET("/loglevel", groups.Admin.GetLogLevel)
+	router.GET("/maintenance", groups.Admin.GetMaintenance)
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	admin := router.Group("", middlewares.Auth.Authenticate(), middlewares.Auth.Require(model.RoleAdmin))
+	admin.PUT("/loglevel", groups.Admin.SetLogLevel)
+	admin.PUT("/maintenance", groups.Admin.SetMaintenance)
+
+	if enablePprof {
+		debug := admin.Group("/debug/pprof")
+		{
+			debug.GET("/", gin.WrapF(pprof.Index))
+			debug.GET("/cmdline", gin.WrapF(pprof.Cmdline))
+			debug.GET("/profile", gin.WrapF(pprof.Profile))
+			debug.GET("/symbol", gin.WrapF(pprof.Symbol))
+			debug.POST("/symbol", gin.WrapF(pprof.Symbol))
+			debug.GET("/trace", gin.WrapF(pprof.Trace))
+			debug.GET("/:name", gin.WrapF(pprof.Index))
+		}
+	}
 }
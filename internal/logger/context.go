@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"context"
+	"song_lib/internal/tracing"
+
+	"github.com/sirupsen/logrus"
+)
+
+type requestIDKey struct{}
+
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// contextHook copies request correlation fields onto entries created with
+// WithContext, so layers only need to pass ctx along to be correlated.
+type contextHook struct{}
+
+func (contextHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (contextHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+
+	if id := RequestID(entry.Context); id != "" {
+		entry.Data["request_id"] = id
+	}
+
+	if sc := tracing.SpanFromContext(entry.Context).Context(); sc.IsValid() {
+		entry.Data["trace_id"] = sc.TraceID.String()
+		entry.Data["span_id"] = sc.SpanID.String()
+	}
+
+	return nil
+}
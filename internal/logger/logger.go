@@ -0,0 +1,25 @@
+package logger
+
+import (
+	"song_lib/internal/config"
+	"song_lib/internal/domain/model"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Configure applies the level, output format and lyric redaction from cfg and
+// installs the hook adding request correlation fields. The config has already
+// been validated, so parse errors cannot happen here.
+func Configure(log *logrus.Logger, cfg *config.Log) {
+	level, err := logrus.ParseLevel(cfg.Level)
+	if err == nil {
+		log.SetLevel(level)
+	}
+
+	if cfg.Format == "json" {
+		log.SetFormatter(&logrus.JSONFormatter{})
+	}
+
+	log.AddHook(contextHook{})
+	model.LyricsLogLimit = cfg.LyricsMaxLen
+}
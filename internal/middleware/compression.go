@@ -0,0 +1,312 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"song_lib/internal/config"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+type Compression struct {
+	enabled        bool
+	minSize        int
+	contentTypes   map[string]bool
+	maxRequestSize int64
+	log            *logrus.Logger
+
+	gzipPool    sync.Pool
+	deflatePool sync.Pool
+}
+
+func NewCompression(cfg *config.Compression, log *logrus.Logger) *Compression {
+	types := make(map[string]bool, len(cfg.ContentTypes))
+	for _, t := range cfg.ContentTypes {
+		types[strings.ToLower(strings.TrimSpace(t))] = true
+	}
+
+	level := cfg.Level
+	return &Compression{
+		enabled:        cfg.Enabled,
+		minSize:        cfg.MinSize,
+		contentTypes:   types,
+		maxRequestSize: cfg.MaxRequestSize,
+		log:            log,
+		gzipPool: sync.Pool{New: func() any {
+			w, _ := gzip.NewWriterLevel(io.Discard, level)
+			return w
+		}},
+		deflatePool: sync.Pool{New: func() any {
+			w, _ := zlib.NewWriterLevel(io.Discard, level)
+			return w
+		}},
+	}
+}
+
+// Compress decodes gzip request bodies and encodes responses with gzip or
+// deflate as negotiated by Accept-Encoding. Responses are buffered up to the
+// minimum size, so small bodies and content types outside the allowlist go
+// out unchanged. It must run before Idempotency so stored responses and
+// request hashes are taken over the plain bytes.
+func (z *Compression) Compress() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !z.decodeRequest(c) {
+			return
+		}
+
+		if !z.enabled {
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &compressWriter{ResponseWriter: c.Writer, owner: z, encoding: encoding}
+		c.Writer = w
+		defer func() {
+			if err := w.finish(); err != nil {
+				z.log.WithContext(c).WithField("op", "internal/middleware/compression/Compress").
+					WithError(err).Error("Failed to finish compressed response")
+			}
+			c.Writer = w.ResponseWriter
+		}()
+
+		c.Next()
+	}
+}
+
+// decodeRequest replaces a gzip-encoded body with its decompressed stream,
+// capped at maxRequestSize. It reports false when the request was aborted.
+func (z *Compression) decodeRequest(c *gin.Context) bool {
+	switch strings.ToLower(strings.TrimSpace(c.GetHeader("Content-Encoding"))) {
+	case "", "identity":
+		return true
+	case encodingGzip, "x-gzip":
+	default:
+		c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, "unsupported content encoding")
+		return false
+	}
+
+	gz, err := gzip.NewReader(c.Request.Body)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, "invalid gzip request body")
+		return false
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, &gzipBody{Reader: gz, orig: c.Request.Body}, z.maxRequestSize)
+	c.Request.Header.Del("Content-Encoding")
+	c.Request.Header.Del("Content-Length")
+	c.Request.ContentLength = -1
+
+	return true
+}
+
+type gzipBody struct {
+	*gzip.Reader
+	orig io.Closer
+}
+
+func (b *gzipBody) Close() error {
+	return errors.Join(b.Reader.Close(), b.orig.Close())
+}
+
+func (z *Compression) compressible(header http.Header) bool {
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+
+	return z.contentTypes[mediaType]
+}
+
+// negotiateEncoding picks gzip or deflate from an Accept-Encoding header,
+// preferring gzip on equal quality. It returns "" when neither is acceptable.
+func negotiateEncoding(header string) string {
+	if header == "" {
+		return ""
+	}
+
+	q := map[string]float64{}
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		quality := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		q[name] = quality
+	}
+
+	weight := func(name string) float64 {
+		if v, ok := q[name]; ok {
+			return v
+		}
+		return q["*"]
+	}
+
+	gzipQ, deflateQ := weight(encodingGzip), weight(encodingDeflate)
+	switch {
+	case gzipQ > 0 && gzipQ >= deflateQ:
+		return encodingGzip
+	case deflateQ > 0:
+		return encodingDeflate
+	default:
+		return ""
+	}
+}
+
+// compressWriter holds the body back until minSize bytes have been written,
+// then decides once whether to compress based on the response headers.
+type compressWriter struct {
+	gin.ResponseWriter
+	owner    *Compression
+	encoding string
+
+	buf     bytes.Buffer
+	decided bool
+	enc     io.WriteCloser
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if w.decided {
+		if w.enc != nil {
+			return w.enc.Write(b)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.buf.Write(b)
+	if w.buf.Len() >= w.owner.minSize {
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(b), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// decide starts compression if the response qualifies and flushes the
+// buffered bytes through the chosen path.
+func (w *compressWriter) decide() error {
+	w.decided = true
+
+	status := w.ResponseWriter.Status()
+	header := w.ResponseWriter.Header()
+	if w.buf.Len() >= w.owner.minSize && bodyAllowed(status) && w.owner.compressible(header) {
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+		// respondCacheable already sends weak ETags; this covers any other
+		// handler that sets a strong one.
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+		w.enc = w.owner.acquire(w.encoding, w.ResponseWriter)
+	}
+
+	if w.buf.Len() == 0 {
+		return nil
+	}
+
+	var err error
+	if w.enc != nil {
+		_, err = w.enc.Write(w.buf.Bytes())
+	} else {
+		_, err = w.ResponseWriter.Write(w.buf.Bytes())
+	}
+	w.buf.Reset()
+
+	return err
+}
+
+func (w *compressWriter) finish() error {
+	if !w.decided {
+		if err := w.decide(); err != nil {
+			return err
+		}
+	}
+	if w.enc == nil {
+		return nil
+	}
+
+	err := w.enc.Close()
+	w.owner.release(w.encoding, w.enc)
+	w.enc = nil
+
+	return err
+}
+
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if f, ok := w.enc.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return w.ResponseWriter.Hijack()
+}
+
+func (z *Compression) acquire(encoding string, dst io.Writer) io.WriteCloser {
+	if encoding == encodingGzip {
+		w := z.gzipPool.Get().(*gzip.Writer)
+		w.Reset(dst)
+		return w
+	}
+
+	w := z.deflatePool.Get().(*zlib.Writer)
+	w.Reset(dst)
+	return w
+}
+
+func (z *Compression) release(encoding string, w io.WriteCloser) {
+	if encoding == encodingGzip {
+		z.gzipPool.Put(w)
+		return
+	}
+	z.deflatePool.Put(w)
+}
+
+func bodyAllowed(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
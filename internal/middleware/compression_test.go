@@ -0,0 +1,33 @@
+package middleware
+
+import "testing"
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "no header", header: "", want: ""},
+		{name: "gzip only", header: "gzip", want: encodingGzip},
+		{name: "deflate only", header: "deflate", want: encodingDeflate},
+		{name: "gzip wins a tie", header: "deflate, gzip", want: encodingGzip},
+		{name: "higher quality wins", header: "gzip;q=0.5, deflate;q=0.8", want: encodingDeflate},
+		{name: "case and spaces", header: " GZIP ; q=1 ", want: encodingGzip},
+		{name: "gzip refused", header: "gzip;q=0, deflate", want: encodingDeflate},
+		{name: "both refused", header: "gzip;q=0, deflate;q=0", want: ""},
+		{name: "identity only", header: "identity", want: ""},
+		{name: "wildcard", header: "*", want: encodingGzip},
+		{name: "wildcard does not override explicit refusal", header: "gzip;q=0, *;q=0.5", want: encodingDeflate},
+		{name: "wildcard refused", header: "br, *;q=0", want: ""},
+		{name: "unparsable quality is ignored", header: "gzip;q=abc, deflate", want: encodingDeflate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := negotiateEncoding(tt.header); got != tt.want {
+				t.Errorf("negotiateEncoding(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"net/http"
+	"song_lib/internal/config"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CORS struct {
+	origins          map[string]bool
+	anyOrigin        bool
+	methods          map[string]bool
+	headers          map[string]bool
+	allowMethods     string
+	allowHeaders     string
+	exposeHeaders    string
+	allowCredentials bool
+	maxAge           string
+}
+
+func NewCORS(cfg *config.CORS) *CORS {
+	c := &CORS{
+		origins:          make(map[string]bool, len(cfg.AllowedOrigins)),
+		methods:          make(map[string]bool, len(cfg.AllowedMethods)),
+		headers:          make(map[string]bool, len(cfg.AllowedHeaders)),
+		allowMethods:     strings.Join(cfg.AllowedMethods, ", "),
+		allowHeaders:     strings.Join(cfg.AllowedHeaders, ", "),
+		exposeHeaders:    strings.Join(cfg.ExposedHeaders, ", "),
+		allowCredentials: cfg.AllowCredentials,
+		maxAge:           strconv.Itoa(int(cfg.MaxAge.Seconds())),
+	}
+
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			c.anyOrigin = true
+			continue
+		}
+		c.origins[strings.ToLower(origin)] = true
+	}
+	for _, method := range cfg.AllowedMethods {
+		c.methods[strings.ToUpper(method)] = true
+	}
+	for _, header := range cfg.AllowedHeaders {
+		c.headers[http.CanonicalHeaderKey(header)] = true
+	}
+
+	return c
+}
+
+// Handle answers preflight requests and adds CORS headers to actual requests
+// from allowed origins. It must run before Auth.Authenticate, since browsers
+// send preflights without credentials. Requests from other origins are passed
+// through without CORS headers and the browser blocks the response.
+func (cr *CORS) Handle() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !cr.anyOrigin && len(cr.origins) == 0 {
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Origin")
+
+		origin := c.GetHeader("Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if preflight {
+			c.Writer.Header().Add("Vary", "Access-Control-Request-Method")
+			c.Writer.Header().Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		if !cr.allowedOrigin(origin) {
+			if preflight {
+				c.AbortWithStatusJSON(http.StatusForbidden, "origin not allowed")
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if cr.anyOrigin && !cr.allowCredentials {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Access-Control-Allow-Origin", origin)
+		}
+		if cr.allowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if cr.exposeHeaders != "" {
+				c.Header("Access-Control-Expose-Headers", cr.exposeHeaders)
+			}
+			c.Next()
+			return
+		}
+
+		if !cr.methods[strings.ToUpper(c.GetHeader("Access-Control-Request-Method"))] {
+			c.AbortWithStatusJSON(http.StatusForbidden, "method not allowed by CORS policy")
+			return
+		}
+		for _, header := range strings.Split(c.GetHeader("Access-Control-Request-Headers"), ",") {
+			header = strings.TrimSpace(header)
+			if header != "" && !cr.headers[http.CanonicalHeaderKey(header)] {
+				c.AbortWithStatusJSON(http.StatusForbidden, "header "+header+" not allowed by CORS policy")
+				return
+			}
+		}
+
+		c.Header("Access-Control-Allow-Methods", cr.allowMethods)
+		if cr.allowHeaders != "" {
+			c.Header("Access-Control-Allow-Headers", cr.allowHeaders)
+		}
+		c.Header("Access-Control-Max-Age", cr.maxAge)
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+// Preflight is the route handler for OPTIONS requests that Handle did not
+// answer, so the group middleware runs for every path under the group.
+func (cr *CORS) Preflight(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
+
+func (cr *CORS) allowedOrigin(origin string) bool {
+	return cr.anyOrigin || cr.origins[strings.ToLower(origin)]
+}
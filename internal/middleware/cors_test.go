@@ -0,0 +1,176 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"song_lib/internal/config"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCORSHandle(t *testing.T) {
+	base := config.CORS{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"Authorization", "Content-Type"},
+		ExposedHeaders: []string{"ETag"},
+		MaxAge:         10 * time.Minute,
+	}
+
+	tests := []struct {
+		name        string
+		cfg         func(c *config.CORS)
+		method      string
+		headers     map[string]string
+		wantStatus  int
+		wantHeaders map[string]string
+	}{
+		{
+			name:        "disabled without origins",
+			cfg:         func(c *config.CORS) { c.AllowedOrigins = nil },
+			method:      http.MethodGet,
+			headers:     map[string]string{"Origin": "https://app.example.com"},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "", "Vary": ""},
+		},
+		{
+			name:        "same-origin request",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "", "Vary": "Origin"},
+		},
+		{
+			name:       "allowed origin",
+			method:     http.MethodGet,
+			headers:    map[string]string{"Origin": "https://app.example.com"},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.example.com",
+				"Access-Control-Expose-Headers":    "ETag",
+				"Access-Control-Allow-Credentials": "",
+			},
+		},
+		{
+			name:        "origin match ignores case",
+			method:      http.MethodGet,
+			headers:     map[string]string{"Origin": "https://APP.example.com"},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "https://APP.example.com"},
+		},
+		{
+			name:        "disallowed origin passes through without headers",
+			method:      http.MethodGet,
+			headers:     map[string]string{"Origin": "https://evil.example.com"},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:        "any origin without credentials",
+			cfg:         func(c *config.CORS) { c.AllowedOrigins = []string{"*"} },
+			method:      http.MethodGet,
+			headers:     map[string]string{"Origin": "https://other.example.com"},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "*"},
+		},
+		{
+			name: "any origin with credentials echoes the origin",
+			cfg: func(c *config.CORS) {
+				c.AllowedOrigins = []string{"*"}
+				c.AllowCredentials = true
+			},
+			method:     http.MethodGet,
+			headers:    map[string]string{"Origin": "https://other.example.com"},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://other.example.com",
+				"Access-Control-Allow-Credentials": "true",
+			},
+		},
+		{
+			name:   "preflight",
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "https://app.example.com",
+				"Access-Control-Request-Method":  "post",
+				"Access-Control-Request-Headers": "content-type, authorization",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":   "https://app.example.com",
+				"Access-Control-Allow-Methods":  "GET, POST",
+				"Access-Control-Allow-Headers":  "Authorization, Content-Type",
+				"Access-Control-Max-Age":        "600",
+				"Access-Control-Expose-Headers": "",
+			},
+		},
+		{
+			name:   "preflight from disallowed origin",
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://evil.example.com",
+				"Access-Control-Request-Method": "GET",
+			},
+			wantStatus:  http.StatusForbidden,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:   "preflight for disallowed method",
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://app.example.com",
+				"Access-Control-Request-Method": "DELETE",
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:   "preflight for disallowed header",
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "https://app.example.com",
+				"Access-Control-Request-Method":  "GET",
+				"Access-Control-Request-Headers": "Authorization, X-Custom",
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "plain OPTIONS is not a preflight",
+			method:      http.MethodOptions,
+			headers:     map[string]string{"Origin": "https://app.example.com"},
+			wantStatus:  http.StatusNoContent,
+			wantHeaders: map[string]string{"Access-Control-Allow-Methods": "", "Access-Control-Expose-Headers": "ETag"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base
+			if tt.cfg != nil {
+				tt.cfg(&cfg)
+			}
+			cors := NewCORS(&cfg)
+
+			router := gin.New()
+			router.Use(cors.Handle())
+			router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+			router.OPTIONS("/", cors.Preflight)
+
+			req := httptest.NewRequest(tt.method, "/", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			for k, want := range tt.wantHeaders {
+				if got := w.Header().Get(k); got != want {
+					t.Errorf("%s = %q, want %q", k, got, want)
+				}
+			}
+		})
+	}
+}
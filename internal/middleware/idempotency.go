@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	maxIdempotencyKeyLen = 255
+)
+
+type Idempotency struct {
+	idempotencyUsecase usecase.Idempotency
+	maxBodySize        int64
+	log                *logrus.Logger
+}
+
+func NewIdempotency(idempotencyUsecase usecase.Idempotency, maxBodySize int64, log *logrus.Logger) *Idempotency {
+	return &Idempotency{
+		idempotencyUsecase: idempotencyUsecase,
+		maxBodySize:        maxBodySize,
+		log:                log,
+	}
+}
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
+
+// Handle makes a write endpoint idempotent for requests carrying an
+// Idempotency-Key header. Keys are scoped to the authenticated caller, so it
+// must run after Auth.Authenticate. The first response is stored and replayed
+// for repeats with the same body; reusing a key for a different request is a
+// 422. Server errors and panics release the key so the client can retry.
+func (i *Idempotency) Handle() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		log := i.log.WithContext(c).WithField("op", "internal/middleware/idempotency/Handle")
+
+		if len(key) > maxIdempotencyKeyLen {
+			c.AbortWithStatusJSON(http.StatusBadRequest, "idempotency key is too long")
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, i.maxBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, "request body is too large")
+				return
+			}
+			log.WithError(err).Error("Failed to read request body")
+			c.AbortWithStatusJSON(http.StatusBadRequest, "invalid request body")
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope := "anonymous"
+		if principal, ok := PrincipalFrom(c); ok {
+			scope = principal.Subject
+		}
+		hash := requestHash(c.Request.Method, c.Request.URL.RequestURI(), body)
+
+		existing, created, err := i.idempotencyUsecase.Begin(c, scope, key, hash)
+		if err != nil {
+			log.WithError(err).Error("Failed to reserve idempotency key")
+			c.AbortWithStatusJSON(http.StatusInternalServerError, "something went wrong")
+			return
+		}
+
+		if !created {
+			switch {
+			case existing.RequestHash != hash:
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, &model.ValidationError{
+					Errors: []model.FieldError{{
+						Field:   idempotencyKeyHeader,
+						Message: "was already used for a different request",
+					}},
+				})
+			case !existing.Completed():
+				c.AbortWithStatusJSON(http.StatusConflict, "a request with this idempotency key is in progress")
+			default:
+				log.Infof("Replaying stored response for idempotency key %s", key)
+				c.Header("Idempotent-Replayed", "true")
+				c.Data(existing.Status, existing.ContentType, existing.Body)
+				c.Abort()
+			}
+			return
+		}
+
+		// The client may have gone away; the outcome must be recorded anyway.
+		ctx := context.WithoutCancel(c.Request.Context())
+		release := func() {
+			if err := i.idempotencyUsecase.Release(ctx, scope, key); err != nil {
+				log.WithError(err).Error("Failed to release idempotency key")
+			}
+		}
+
+		// Without this a panicking handler would leave the key in progress
+		// until it expires.
+		defer func() {
+			if p := recover(); p != nil {
+				release()
+				panic(p)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			release()
+			return
+		}
+
+		err = i.idempotencyUsecase.Complete(ctx, model.IdempotencyRecord{
+			Scope:       scope,
+			Key:         key,
+			RequestHash: hash,
+			Status:      status,
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		})
+		if err != nil {
+			log.WithError(err).Error("Failed to store idempotent response")
+		}
+	}
+}
+
+// requestHash covers the query string too, so flags such as dry_run make a
+// different request.
+func requestHash(method, uri string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(uri))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
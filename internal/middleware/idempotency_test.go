@@ -0,0 +1,191 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"song_lib/internal/domain/model"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fakeIdempotency struct {
+	mu      sync.Mutex
+	records map[string]model.IdempotencyRecord
+}
+
+func newFakeIdempotency() *fakeIdempotency {
+	return &fakeIdempotency{records: map[string]model.IdempotencyRecord{}}
+}
+
+func (f *fakeIdempotency) Begin(_ context.Context, scope, key, requestHash string) (model.IdempotencyRecord, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if existing, ok := f.records[scope+"/"+key]; ok {
+		return existing, false, nil
+	}
+	f.records[scope+"/"+key] = model.IdempotencyRecord{Scope: scope, Key: key, RequestHash: requestHash}
+	return model.IdempotencyRecord{}, true, nil
+}
+
+func (f *fakeIdempotency) Complete(_ context.Context, record model.IdempotencyRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.records[record.Scope+"/"+record.Key] = record
+	return nil
+}
+
+func (f *fakeIdempotency) Release(_ context.Context, scope, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.records, scope+"/"+key)
+	return nil
+}
+
+func (f *fakeIdempotency) RunCleanup(time.Duration) func(ctx context.Context) error {
+	return func(context.Context) error { return nil }
+}
+
+func TestIdempotencyHandle(t *testing.T) {
+	type request struct {
+		key        string
+		body       string
+		path       string
+		wantStatus int
+		wantBody   string
+		wantReplay bool
+		wantCalls  int
+	}
+
+	tests := []struct {
+		name     string
+		stored   []model.IdempotencyRecord
+		requests []request
+	}{
+		{
+			name: "no key runs every time",
+			requests: []request{
+				{body: "a", wantStatus: http.StatusCreated, wantBody: "created 1", wantCalls: 1},
+				{body: "a", wantStatus: http.StatusCreated, wantBody: "created 2", wantCalls: 2},
+			},
+		},
+		{
+			name: "repeat is replayed",
+			requests: []request{
+				{key: "k", body: "a", wantStatus: http.StatusCreated, wantBody: "created 1", wantCalls: 1},
+				{key: "k", body: "a", wantStatus: http.StatusCreated, wantBody: "created 1", wantReplay: true, wantCalls: 1},
+			},
+		},
+		{
+			name: "different body is rejected",
+			requests: []request{
+				{key: "k", body: "a", wantStatus: http.StatusCreated, wantCalls: 1},
+				{key: "k", body: "b", wantStatus: http.StatusUnprocessableEntity, wantCalls: 1},
+			},
+		},
+		{
+			name: "different query is rejected",
+			requests: []request{
+				{key: "k", body: "a", wantStatus: http.StatusCreated, wantCalls: 1},
+				{key: "k", body: "a", path: "/?dry_run=true", wantStatus: http.StatusUnprocessableEntity, wantCalls: 1},
+			},
+		},
+		{
+			name: "request in progress",
+			stored: []model.IdempotencyRecord{
+				{Scope: "anonymous", Key: "k", RequestHash: requestHash(http.MethodPost, "/", []byte("a"))},
+			},
+			requests: []request{
+				{key: "k", body: "a", wantStatus: http.StatusConflict, wantCalls: 0},
+			},
+		},
+		{
+			name: "server error releases the key",
+			requests: []request{
+				{key: "k", body: "fail", wantStatus: http.StatusInternalServerError, wantCalls: 1},
+				{key: "k", body: "fail", wantStatus: http.StatusInternalServerError, wantCalls: 2},
+			},
+		},
+		{
+			name: "panic releases the key",
+			requests: []request{
+				{key: "k", body: "panic", wantStatus: http.StatusInternalServerError, wantCalls: 1},
+				{key: "k", body: "a", wantStatus: http.StatusCreated, wantBody: "created 2", wantCalls: 2},
+			},
+		},
+		{
+			name: "key too long",
+			requests: []request{
+				{key: strings.Repeat("k", maxIdempotencyKeyLen+1), body: "a", wantStatus: http.StatusBadRequest, wantCalls: 0},
+			},
+		},
+		{
+			name: "body too large",
+			requests: []request{
+				{key: "k", body: strings.Repeat("a", 65), wantStatus: http.StatusRequestEntityTooLarge, wantCalls: 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeIdempotency()
+			for _, r := range tt.stored {
+				store.records[r.Scope+"/"+r.Key] = r
+			}
+
+			calls := 0
+			router := gin.New()
+			router.Use(gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, _ any) {
+				c.AbortWithStatus(http.StatusInternalServerError)
+			}))
+			router.Use(NewIdempotency(store, 64, quietLogger()).Handle())
+			router.POST("/", func(c *gin.Context) {
+				calls++
+				body, _ := c.GetRawData()
+				switch string(body) {
+				case "fail":
+					c.JSON(http.StatusInternalServerError, "failed")
+				case "panic":
+					panic("handler panic")
+				default:
+					c.String(http.StatusCreated, "created %d", calls)
+				}
+			})
+
+			for i, r := range tt.requests {
+				path := r.path
+				if path == "" {
+					path = "/"
+				}
+				req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(r.body))
+				if r.key != "" {
+					req.Header.Set(idempotencyKeyHeader, r.key)
+				}
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				if w.Code != r.wantStatus {
+					t.Fatalf("request %d: status = %d, want %d", i, w.Code, r.wantStatus)
+				}
+				if r.wantBody != "" && w.Body.String() != r.wantBody {
+					t.Errorf("request %d: body = %q, want %q", i, w.Body.String(), r.wantBody)
+				}
+				if replayed := w.Header().Get("Idempotent-Replayed") == "true"; replayed != r.wantReplay {
+					t.Errorf("request %d: replayed = %v, want %v", i, replayed, r.wantReplay)
+				}
+				if calls != r.wantCalls {
+					t.Errorf("request %d: handler ran %d times, want %d", i, calls, r.wantCalls)
+				}
+			}
+		})
+	}
+}
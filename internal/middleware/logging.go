@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// AccessLog writes one entry per request. It must run after RequestID so the
+// entry carries the request id.
+func AccessLog(log *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+
+		c.Next()
+
+		entry := log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+			"status":     c.Writer.Status(),
+			"method":     c.Request.Method,
+			"path":       path,
+			"route":      c.FullPath(),
+			"latency_ms": time.Since(start).Milliseconds(),
+			"client_ip":  c.ClientIP(),
+			"user_agent": c.Request.UserAgent(),
+			"size":       c.Writer.Size(),
+		})
+		if principal, ok := PrincipalFrom(c); ok {
+			entry = entry.WithField("subject", principal.Subject)
+		}
+
+		switch status := c.Writer.Status(); {
+		case len(c.Errors) > 0:
+			entry.Error(c.Errors.ByType(gin.ErrorTypePrivate).String())
+		case status >= 500:
+			entry.Error("request completed")
+		case status >= 400:
+			entry.Warn("request completed")
+		default:
+			entry.Info("request completed")
+		}
+	}
+}
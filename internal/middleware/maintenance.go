@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"song_lib/internal/config"
+	"song_lib/internal/domain/usecase"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Maintenance struct {
+	maintenanceUsecase usecase.Maintenance
+	retryAfter         string
+	message            string
+}
+
+func NewMaintenance(cfg *config.Maintenance, maintenanceUsecase usecase.Maintenance) *Maintenance {
+	return &Maintenance{
+		maintenanceUsecase: maintenanceUsecase,
+		retryAfter:         strconv.Itoa(int(cfg.RetryAfter.Seconds())),
+		message:            cfg.Message,
+	}
+}
+
+// ReadOnly rejects every non-read method with 503 while maintenance mode is
+// on. It must run before Idempotency so refused writes don't reserve keys.
+func (m *Maintenance) ReadOnly() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if isReadMethod(c.Request.Method) || !m.maintenanceUsecase.ReadOnly() {
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", m.retryAfter)
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, m.message)
+	}
+}
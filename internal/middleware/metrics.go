@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"song_lib/internal/metrics"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Metrics struct {
+	metrics *metrics.Metrics
+}
+
+func NewMetrics(m *metrics.Metrics) *Metrics {
+	return &Metrics{
+		metrics: m,
+	}
+}
+
+// Observe records request counts and latencies labelled by the matched route
+// template rather than the raw path, so ids don't explode cardinality.
+func (m *Metrics) Observe() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		m.metrics.HTTPRequests.WithLabelValues(c.Request.Method, route, status).Inc()
+		m.metrics.HTTPDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"song_lib/internal/config"
+	"song_lib/internal/metrics"
+	"song_lib/internal/tracing"
+	"song_lib/internal/usecase"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Middlewares struct {
+	*Auth
+	*RateLimit
+	*Metrics
+	*Tracing
+	*Idempotency
+	*Compression
+	*CORS
+	*Maintenance
+}
+
+func NewMiddlewares(cfg *config.Config, log *logrus.Logger, m *metrics.Metrics, tracer *tracing.Tracer, usecases *usecase.Usecases) (*Middlewares, error) {
+	auth, err := NewAuth(&cfg.Auth, log)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Middlewares{
+		Auth:        auth,
+		RateLimit:   NewRateLimit(&cfg.RateLimit, log),
+		Metrics:     NewMetrics(m),
+		Tracing:     NewTracing(tracer),
+		Idempotency: NewIdempotency(usecases.Idempotency, cfg.Idempotency.MaxBodySize, log),
+		Compression: NewCompression(&cfg.Compression, log),
+		CORS:        NewCORS(&cfg.CORS),
+		Maintenance: NewMaintenance(&cfg.Maintenance, usecases.Maintenance),
+	}, nil
+}
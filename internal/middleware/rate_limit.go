@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"song_lib/internal/config"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+type limiterPool struct {
+	limit rate.Limit
+	burst int
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+func newLimiterPool(rps float64, burst int) *limiterPool {
+	return &limiterPool{
+		limit:   rate.Limit(rps),
+		burst:   burst,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+func (p *limiterPool) get(key string, now time.Time) *rate.Limiter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	b, ok := p.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(p.limit, p.burst)}
+		p.buckets[key] = b
+	}
+	b.lastSeen = now
+
+	return b.limiter
+}
+
+func (p *limiterPool) sweep(now time.Time, ttl time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, b := range p.buckets {
+		if now.Sub(b.lastSeen) > ttl {
+			delete(p.buckets, key)
+		}
+	}
+}
+
+type RateLimit struct {
+	enabled bool
+	read    *limiterPool
+	write   *limiterPool
+	idleTTL time.Duration
+	log     *logrus.Logger
+
+	sweepMu   sync.Mutex
+	lastSweep time.Time
+}
+
+func NewRateLimit(cfg *config.RateLimit, log *logrus.Logger) *RateLimit {
+	return &RateLimit{
+		enabled:   cfg.Enabled,
+		read:      newLimiterPool(cfg.ReadRPS, cfg.ReadBurst),
+		write:     newLimiterPool(cfg.WriteRPS, cfg.WriteBurst),
+		idleTTL:   cfg.IdleTTL,
+		log:       log,
+		lastSweep: time.Now(),
+	}
+}
+
+// Limit applies a per-client token bucket. Clients are identified by the
+// authenticated subject when present and by IP otherwise, so it must run
+// after Auth.Authenticate. Safe methods draw from the read bucket, everything
+// else from the write bucket.
+func (r *RateLimit) Limit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !r.enabled {
+			c.Next()
+			return
+		}
+
+		now := time.Now()
+		r.maybeSweep(now)
+
+		pool := r.write
+		if isReadMethod(c.Request.Method) {
+			pool = r.read
+		}
+
+		key := clientKey(c)
+		limiter := pool.get(key, now)
+
+		allowed := limiter.AllowN(now, 1)
+		tokens := limiter.TokensAt(now)
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(pool.burst))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(int(math.Max(0, math.Floor(tokens)))))
+		c.Header("X-RateLimit-Reset", strconv.Itoa(secondsUntil(float64(pool.burst)-tokens, pool.limit)))
+
+		if !allowed {
+			retryAfter := secondsUntil(1-tokens, pool.limit)
+
+			r.log.WithContext(c).WithField("op", "internal/middleware/rate_limit/Limit").
+				Warnf("Rate limit exceeded for %s on %s %s", key, c.Request.Method, c.FullPath())
+
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func (r *RateLimit) maybeSweep(now time.Time) {
+	r.sweepMu.Lock()
+	if now.Sub(r.lastSweep) < r.idleTTL {
+		r.sweepMu.Unlock()
+		return
+	}
+	r.lastSweep = now
+	r.sweepMu.Unlock()
+
+	r.read.sweep(now, r.idleTTL)
+	r.write.sweep(now, r.idleTTL)
+}
+
+func clientKey(c *gin.Context) string {
+	if principal, ok := PrincipalFrom(c); ok {
+		return "sub:" + principal.Subject
+	}
+
+	return "ip:" + c.ClientIP()
+}
+
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// secondsUntil returns how many whole seconds it takes to refill the given
+// number of tokens.
+func secondsUntil(tokens float64, limit rate.Limit) int {
+	if tokens <= 0 {
+		return 0
+	}
+	if limit <= 0 {
+		return math.MaxInt32
+	}
+
+	return int(math.Ceil(tokens / float64(limit)))
+}
@@ -0,0 +1,249 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"song_lib/internal/config"
+	"song_lib/internal/domain/model"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func quietLogger() *logrus.Logger {
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+	return log
+}
+
+func TestSecondsUntil(t *testing.T) {
+	tests := []struct {
+		name   string
+		tokens float64
+		limit  rate.Limit
+		want   int
+	}{
+		{name: "nothing to refill", tokens: 0, limit: 1, want: 0},
+		{name: "negative deficit", tokens: -2, limit: 1, want: 0},
+		{name: "exact seconds", tokens: 4, limit: 2, want: 2},
+		{name: "rounds up", tokens: 1, limit: 3, want: 1},
+		{name: "fractional tokens", tokens: 2.5, limit: 1, want: 3},
+		{name: "never refills", tokens: 1, limit: 0, want: 2147483647},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := secondsUntil(tt.tokens, tt.limit); got != tt.want {
+				t.Errorf("secondsUntil(%v, %v) = %d, want %d", tt.tokens, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimiterPoolSweep(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ttl := time.Minute
+
+	tests := []struct {
+		name     string
+		lastSeen map[string]time.Time
+		sweepAt  time.Time
+		want     []string
+	}{
+		{
+			name:     "keeps recent buckets",
+			lastSeen: map[string]time.Time{"a": start, "b": start.Add(30 * time.Second)},
+			sweepAt:  start.Add(ttl),
+			want:     []string{"a", "b"},
+		},
+		{
+			name:     "drops idle buckets",
+			lastSeen: map[string]time.Time{"a": start, "b": start.Add(90 * time.Second)},
+			sweepAt:  start.Add(2 * ttl),
+			want:     []string{"b"},
+		},
+		{
+			name:     "drops everything",
+			lastSeen: map[string]time.Time{"a": start, "b": start},
+			sweepAt:  start.Add(time.Hour),
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newLimiterPool(1, 1)
+			for key, seen := range tt.lastSeen {
+				p.get(key, seen)
+			}
+
+			p.sweep(tt.sweepAt, ttl)
+
+			if len(p.buckets) != len(tt.want) {
+				t.Fatalf("got %d buckets, want %d", len(p.buckets), len(tt.want))
+			}
+			for _, key := range tt.want {
+				if _, ok := p.buckets[key]; !ok {
+					t.Errorf("bucket %q was swept", key)
+				}
+			}
+		})
+	}
+}
+
+func TestLimiterPoolGetReusesBucket(t *testing.T) {
+	p := newLimiterPool(1, 1)
+	now := time.Now()
+
+	if p.get("a", now) != p.get("a", now) {
+		t.Error("the same key got different limiters")
+	}
+	if p.get("a", now) == p.get("b", now) {
+		t.Error("different keys share a limiter")
+	}
+}
+
+func TestClientKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		principal      *model.Principal
+		forwardedFor   string
+		want           string
+	}{
+		{
+			name:      "authenticated subject",
+			principal: &model.Principal{Subject: "alice", Role: model.RoleReader},
+			want:      "sub:alice",
+		},
+		{
+			name: "anonymous remote address",
+			want: "ip:192.0.2.1",
+		},
+		{
+			name:         "forwarded header from untrusted peer is ignored",
+			forwardedFor: "203.0.113.9",
+			want:         "ip:192.0.2.1",
+		},
+		{
+			name:           "forwarded header from trusted proxy",
+			trustedProxies: []string{"192.0.2.0/24"},
+			forwardedFor:   "203.0.113.9",
+			want:           "ip:203.0.113.9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, engine := gin.CreateTestContext(httptest.NewRecorder())
+			if err := engine.SetTrustedProxies(tt.trustedProxies); err != nil {
+				t.Fatal(err)
+			}
+
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			c.Request.RemoteAddr = "192.0.2.1:1234"
+			if tt.forwardedFor != "" {
+				c.Request.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			if tt.principal != nil {
+				c.Set(principalKey, *tt.principal)
+			}
+
+			if got := clientKey(c); got != tt.want {
+				t.Errorf("clientKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitLimit(t *testing.T) {
+	cfg := &config.RateLimit{
+		Enabled:    true,
+		ReadRPS:    0.001,
+		ReadBurst:  3,
+		WriteRPS:   0.001,
+		WriteBurst: 1,
+		IdleTTL:    time.Minute,
+	}
+
+	type request struct {
+		method string
+		ip     string
+		want   int
+	}
+
+	tests := []struct {
+		name     string
+		disabled bool
+		requests []request
+	}{
+		{
+			name: "writes are refused after the burst",
+			requests: []request{
+				{method: http.MethodPost, ip: "192.0.2.1", want: http.StatusOK},
+				{method: http.MethodPost, ip: "192.0.2.1", want: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "reads and writes use separate buckets",
+			requests: []request{
+				{method: http.MethodPost, ip: "192.0.2.1", want: http.StatusOK},
+				{method: http.MethodGet, ip: "192.0.2.1", want: http.StatusOK},
+				{method: http.MethodGet, ip: "192.0.2.1", want: http.StatusOK},
+				{method: http.MethodGet, ip: "192.0.2.1", want: http.StatusOK},
+				{method: http.MethodGet, ip: "192.0.2.1", want: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "clients have their own buckets",
+			requests: []request{
+				{method: http.MethodPost, ip: "192.0.2.1", want: http.StatusOK},
+				{method: http.MethodPost, ip: "192.0.2.2", want: http.StatusOK},
+				{method: http.MethodPost, ip: "192.0.2.1", want: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name:     "disabled",
+			disabled: true,
+			requests: []request{
+				{method: http.MethodPost, ip: "192.0.2.1", want: http.StatusOK},
+				{method: http.MethodPost, ip: "192.0.2.1", want: http.StatusOK},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := *cfg
+			c.Enabled = !tt.disabled
+
+			router := gin.New()
+			router.Use(NewRateLimit(&c, quietLogger()).Limit())
+			router.Any("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			for i, r := range tt.requests {
+				req := httptest.NewRequest(r.method, "/", nil)
+				req.RemoteAddr = r.ip + ":1234"
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				if w.Code != r.want {
+					t.Fatalf("request %d: status = %d, want %d", i, w.Code, r.want)
+				}
+				if r.want == http.StatusTooManyRequests && w.Header().Get("Retry-After") == "" {
+					t.Errorf("request %d: missing Retry-After", i)
+				}
+				if !tt.disabled && w.Header().Get("X-RateLimit-Limit") == "" {
+					t.Errorf("request %d: missing X-RateLimit-Limit", i)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"song_lib/internal/tracing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Tracing struct {
+	tracer *tracing.Tracer
+}
+
+func NewTracing(tracer *tracing.Tracer) *Tracing {
+	return &Tracing{
+		tracer: tracer,
+	}
+}
+
+// Trace starts a server span for the request, continuing the trace from an
+// incoming traceparent header when there is one. The span is attached to
+// the request context, so the router must have ContextWithFallback enabled
+// for handlers passing the gin context downstream to see it.
+func (t *Tracing) Trace() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		if sc, ok := tracing.ParseTraceparent(c.GetHeader(tracing.TraceparentHeader)); ok {
+			ctx = tracing.ContextWithRemoteParent(ctx, sc)
+		}
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		ctx, span := t.tracer.Start(ctx, c.Request.Method+" "+route, tracing.SpanKindServer)
+		defer span.End()
+
+		span.SetAttribute("http.request.method", c.Request.Method)
+		span.SetAttribute("http.route", route)
+		span.SetAttribute("url.path", c.Request.URL.Path)
+		span.SetAttribute("client.address", c.ClientIP())
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttribute("http.response.status_code", status)
+		if status >= http.StatusInternalServerError {
+			span.RecordError(errServerError(status))
+		}
+	}
+}
+
+type errServerError int
+
+func (e errServerError) Error() string {
+	return http.StatusText(int(e))
+}
@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"song_lib/internal/domain/model"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+// albumColumns selects a model.Album without tracks from albums a joined
+// with groups g, in the order scanAlbum reads them.
+const albumColumns = "a.id, a.title, a.group_id, g.name, a.release_date, a.cover_link, a.created_at, a.updated_at"
+
+type Album struct {
+	pool *pgxpool.Pool
+	// replica serves the read methods. It is the primary pool when no
+	// replica is configured.
+	replica *pgxpool.Pool
+	log     *logrus.Logger
+}
+
+func NewAlbum(pool, replica *pgxpool.Pool, log *logrus.Logger) *Album {
+	if replica == nil {
+		replica = pool
+	}
+
+	return &Album{
+		pool:    pool,
+		replica: replica,
+		log:     log,
+	}
+}
+
+func scanAlbum(row pgx.Row, album *model.Album) error {
+	return row.Scan(
+		&album.ID,
+		&album.Title,
+		&album.GroupID,
+		&album.Group,
+		&album.ReleaseDate,
+		&album.CoverLink,
+		&album.CreatedAt,
+		&album.UpdatedAt,
+	)
+}
+
+// querier is satisfied by both the pool and a transaction.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// getAlbum reads an album together with its track list.
+func getAlbum(ctx context.Context, q querier, id uint64) (model.Album, error) {
+	query := "SELECT " + albumColumns + " FROM albums a JOIN groups g ON g.id = a.group_id WHERE a.id = $1"
+	tracksQuery := `
+SELECT t.position, t.song_id, s.song
+FROM album_tracks t
+JOIN songs s ON s.id = t.song_id
+WHERE t.album_id = $1
+ORDER BY t.position
+`
+
+	var album model.Album
+	if err := scanAlbum(q.QueryRow(ctx, query, id), &album); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Album{}, fmt.Errorf("album %d: %w", id, model.ErrNotFound)
+		}
+		return model.Album{}, err
+	}
+
+	rows, err := q.Query(ctx, tracksQuery, id)
+	if err != nil {
+		return model.Album{}, err
+	}
+	album.Tracks, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.AlbumTrack, error) {
+		var t model.AlbumTrack
+		err := row.Scan(&t.Position, &t.SongID, &t.Song)
+		return t, err
+	})
+	if err != nil {
+		return model.Album{}, err
+	}
+
+	return album, nil
+}
+
+// replaceTracks swaps the album's track list for songIDs, numbered from 1.
+func replaceTracks(ctx context.Context, tx pgx.Tx, albumID uint64, songIDs []uint64) error {
+	if _, err := tx.Exec(ctx, "DELETE FROM album_tracks WHERE album_id = $1", albumID); err != nil {
+		return err
+	}
+	if len(songIDs) == 0 {
+		return nil
+	}
+
+	query := `
+INSERT INTO album_tracks (album_id, position, song_id)
+SELECT $1, t.position, t.song_id
+FROM unnest($2::bigint[]) WITH ORDINALITY AS t (song_id, position)
+`
+	_, err := tx.Exec(ctx, query, albumID, songIDs)
+	return err
+}
+
+func (a *Album) List(ctx context.Context, request model.AlbumsRequest) ([]model.Album, error) {
+	log := a.log.WithContext(ctx).WithField("op", "internal/repository/album/List")
+
+	query := "SELECT " + albumColumns + " FROM albums a JOIN groups g ON g.id = a.group_id"
+	var args []interface{}
+
+	if request.GroupID != 0 {
+		args = append(args, request.GroupID)
+		query += fmt.Sprintf(" WHERE a.group_id = $%d", len(args))
+	}
+
+	args = append(args, request.PerPage, request.Page*request.PerPage)
+	query += fmt.Sprintf(" ORDER BY a.id LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	log.Debugf("Executing query: %s with %d args", query, len(args))
+
+	rows, err := a.replica.Query(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	albums := []model.Album{}
+	for rows.Next() {
+		album := model.Album{}
+		if err := scanAlbum(rows, &album); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		albums = append(albums, album)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	log.Infof("Successfully retrieved %d albums", len(albums))
+	return albums, nil
+}
+
+func (a *Album) Get(ctx context.Context, id uint64) (model.Album, error) {
+	log := a.log.WithContext(ctx).WithField("op", "internal/repository/album/Get")
+
+	album, err := getAlbum(ctx, a.replica, id)
+	if err != nil {
+		if !errors.Is(err, model.ErrNotFound) {
+			log.Error(err)
+		}
+		return model.Album{}, err
+	}
+
+	return album, nil
+}
+
+func (a *Album) Add(ctx context.Context, album model.AddAlbum) (model.Album, error) {
+	log := a.log.WithContext(ctx).WithField("op", "internal/repository/album/Add")
+
+	log.Debugf("Received album to add: %+v", album)
+
+	query := "INSERT INTO albums (group_id, title, release_date, cover_link) VALUES ($1, $2, $3, $4) RETURNING id"
+
+	var added model.Album
+	err := pgx.BeginFunc(ctx, a.pool, func(tx pgx.Tx) error {
+		var id uint64
+		if err := tx.QueryRow(ctx, query, album.GroupID, album.Title, album.ReleaseDate, album.CoverLink).Scan(&id); err != nil {
+			return err
+		}
+		if err := replaceTracks(ctx, tx, id, album.Tracks); err != nil {
+			return err
+		}
+
+		var err error
+		added, err = getAlbum(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return model.Album{}, a.mapError(log, err, album.Title)
+	}
+
+	log.Infof("Successfully added album with ID: %d", added.ID)
+	return added, nil
+}
+
+func (a *Album) Update(ctx context.Context, album model.UpdateAlbum) (model.Album, error) {
+	log := a.log.WithContext(ctx).WithField("op", "internal/repository/album/Update")
+
+	log.Debugf("Received album to update: %+v", album)
+
+	query := "UPDATE albums SET"
+	var args []interface{}
+	argID := 1
+
+	if album.Title != "" {
+		query += fmt.Sprintf(" title = $%d,", argID)
+		argID++
+		args = append(args, album.Title)
+	}
+	if album.GroupID != 0 {
+		query += fmt.Sprintf(" group_id = $%d,", argID)
+		argID++
+		args = append(args, album.GroupID)
+	}
+	if album.ReleaseDate != "" {
+		query += fmt.Sprintf(" release_date = $%d,", argID)
+		argID++
+		args = append(args, album.ReleaseDate)
+	}
+	if album.CoverLink != "" {
+		query += fmt.Sprintf(" cover_link = $%d,", argID)
+		argID++
+		args = append(args, album.CoverLink)
+	}
+
+	query += fmt.Sprintf(" updated_at = now() WHERE id = $%d", argID)
+	args = append(args, album.ID)
+
+	log.Debugf("Executing query: %s", query)
+
+	var updated model.Album
+	err := pgx.BeginFunc(ctx, a.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("album %d: %w", album.ID, model.ErrNotFound)
+		}
+
+		updated, err = getAlbum(ctx, tx, album.ID)
+		return err
+	})
+	if err != nil {
+		return model.Album{}, a.mapError(log, err, album.Title)
+	}
+
+	log.Infof("Successfully updated album with ID: %d", updated.ID)
+	return updated, nil
+}
+
+func (a *Album) SetTracks(ctx context.Context, request model.SetAlbumTracks) (model.Album, error) {
+	log := a.log.WithContext(ctx).WithField("op", "internal/repository/album/SetTracks")
+
+	log.Debugf("Setting %d tracks on album ID: %d", len(request.Tracks), request.AlbumID)
+
+	var updated model.Album
+	err := pgx.BeginFunc(ctx, a.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, "UPDATE albums SET updated_at = now() WHERE id = $1", request.AlbumID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("album %d: %w", request.AlbumID, model.ErrNotFound)
+		}
+		if err := replaceTracks(ctx, tx, request.AlbumID, request.Tracks); err != nil {
+			return err
+		}
+
+		updated, err = getAlbum(ctx, tx, request.AlbumID)
+		return err
+	})
+	if err != nil {
+		return model.Album{}, a.mapError(log, err, "")
+	}
+
+	log.Infof("Successfully set %d tracks on album ID: %d", len(updated.Tracks), updated.ID)
+	return updated, nil
+}
+
+func (a *Album) Delete(ctx context.Context, id uint64) error {
+	log := a.log.WithContext(ctx).WithField("op", "internal/repository/album/Delete")
+
+	log.Infof("Attempting to delete album with ID: %d", id)
+
+	tag, err := a.pool.Exec(ctx, "DELETE FROM albums WHERE id = $1", id)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("album %d: %w", id, model.ErrNotFound)
+	}
+
+	log.Infof("Successfully deleted album with ID: %d", id)
+	return nil
+}
+
+// mapError turns constraint violations from writing an album into the
+// domain errors the handlers understand.
+func (a *Album) mapError(log *logrus.Entry, err error, title string) error {
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return err
+	case hasCode(err, foreignKeyViolation):
+		return fmt.Errorf("unknown group or song: %w", model.ErrNotFound)
+	case hasCode(err, uniqueViolation):
+		return fmt.Errorf("album %q already exists for this group: %w", title, model.ErrConflict)
+	}
+
+	log.Error(err)
+	return err
+}
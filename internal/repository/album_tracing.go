@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/repository"
+	"song_lib/internal/tracing"
+)
+
+// albumTracing wraps every repository.Album method in a span.
+type albumTracing struct {
+	next   repository.Album
+	tracer *tracing.Tracer
+}
+
+func newAlbumTracing(next repository.Album, tracer *tracing.Tracer) *albumTracing {
+	return &albumTracing{
+		next:   next,
+		tracer: tracer,
+	}
+}
+
+func (a *albumTracing) List(ctx context.Context, request model.AlbumsRequest) ([]model.Album, error) {
+	ctx, span := a.tracer.Start(ctx, "repository.Album/List", tracing.SpanKindInternal)
+	defer span.End()
+
+	albums, err := a.next.List(ctx, request)
+	span.RecordError(err)
+	return albums, err
+}
+
+func (a *albumTracing) Get(ctx context.Context, id uint64) (model.Album, error) {
+	ctx, span := a.tracer.Start(ctx, "repository.Album/Get", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("album.id", id)
+
+	album, err := a.next.Get(ctx, id)
+	span.RecordError(err)
+	return album, err
+}
+
+func (a *albumTracing) Add(ctx context.Context, album model.AddAlbum) (model.Album, error) {
+	ctx, span := a.tracer.Start(ctx, "repository.Album/Add", tracing.SpanKindInternal)
+	defer span.End()
+
+	added, err := a.next.Add(ctx, album)
+	span.RecordError(err)
+	return added, err
+}
+
+func (a *albumTracing) Update(ctx context.Context, album model.UpdateAlbum) (model.Album, error) {
+	ctx, span := a.tracer.Start(ctx, "repository.Album/Update", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("album.id", album.ID)
+
+	updated, err := a.next.Update(ctx, album)
+	span.RecordError(err)
+	return updated, err
+}
+
+func (a *albumTracing) SetTracks(ctx context.Context, request model.SetAlbumTracks) (model.Album, error) {
+	ctx, span := a.tracer.Start(ctx, "repository.Album/SetTracks", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("album.id", request.AlbumID)
+
+	updated, err := a.next.SetTracks(ctx, request)
+	span.RecordError(err)
+	return updated, err
+}
+
+func (a *albumTracing) Delete(ctx context.Context, id uint64) error {
+	ctx, span := a.tracer.Start(ctx, "repository.Album/Delete", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("album.id", id)
+
+	err := a.next.Delete(ctx, id)
+	span.RecordError(err)
+	return err
+}
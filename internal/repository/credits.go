@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Every song credits its own group (songs.group_id) as primary at position
+// 0 in song_artists; further credits follow from position 1.
+
+// loadCredits fills in the Artists of each song.
+func loadCredits(ctx context.Context, q querier, songs []model.Song) error {
+	ids := make([]uint64, len(songs))
+	for i := range songs {
+		ids[i] = songs[i].ID
+	}
+
+	credits, err := creditsBySong(ctx, q, ids)
+	if err != nil {
+		return err
+	}
+	for i := range songs {
+		songs[i].Artists = credits[songs[i].ID]
+	}
+
+	return nil
+}
+
+// creditsBySong reads the credits of the given songs in credit order. Every
+// requested song has an entry, empty when it has no credits.
+func creditsBySong(ctx context.Context, q querier, songIDs []uint64) (map[uint64][]model.ArtistCredit, error) {
+	credits := make(map[uint64][]model.ArtistCredit, len(songIDs))
+	if len(songIDs) == 0 {
+		return credits, nil
+	}
+	for _, id := range songIDs {
+		credits[id] = []model.ArtistCredit{}
+	}
+
+	query := `
+SELECT sa.song_id, sa.group_id, g.name, sa.role
+FROM song_artists sa
+JOIN groups g ON g.id = sa.group_id
+WHERE sa.song_id = ANY($1)
+ORDER BY sa.song_id, sa.position, g.name
+`
+
+	rows, err := q.Query(ctx, query, songIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var songID uint64
+		var credit model.ArtistCredit
+		if err := rows.Scan(&songID, &credit.GroupID, &credit.Name, &credit.Role); err != nil {
+			return nil, err
+		}
+		if _, ok := credits[songID]; ok {
+			credits[songID] = append(credits[songID], credit)
+		}
+	}
+
+	return credits, rows.Err()
+}
+
+// replaceCredits rewrites all credits of a song: its own group as primary
+// followed by the given credits. Credits for the song's own group are
+// skipped.
+func replaceCredits(ctx context.Context, tx pgx.Tx, songID, groupID uint64, credits []model.ArtistCredit) error {
+	if _, err := tx.Exec(ctx, "DELETE FROM song_artists WHERE song_id = $1", songID); err != nil {
+		return err
+	}
+	if err := addMainCredits(ctx, tx, []uint64{songID}, groupID); err != nil {
+		return err
+	}
+
+	query := `
+INSERT INTO song_artists (song_id, group_id, role, position) VALUES ($1, $2, $3, $4)
+ON CONFLICT (song_id, group_id) DO NOTHING
+`
+	for i, credit := range credits {
+		creditID, err := ensureGroup(ctx, tx, credit.Name)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, query, songID, creditID, credit.Role, i+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dropMainCredits removes the credit of each song's own group. It must run
+// before songs.group_id changes, followed by addMainCredits.
+func dropMainCredits(ctx context.Context, tx pgx.Tx, songIDs []uint64) error {
+	query := `
+DELETE FROM song_artists sa
+USING songs s
+WHERE s.id = ANY($1) AND sa.song_id = s.id AND sa.group_id = s.group_id
+`
+	_, err := tx.Exec(ctx, query, songIDs)
+	return err
+}
+
+// addMainCredits credits groupID as primary on each song, taking over any
+// other role it had there.
+func addMainCredits(ctx context.Context, tx pgx.Tx, songIDs []uint64, groupID uint64) error {
+	query := `
+INSERT INTO song_artists (song_id, group_id, role, position)
+SELECT id, $2, 'primary', 0 FROM unnest($1::bigint[]) AS id
+ON CONFLICT (song_id, group_id) DO UPDATE SET role = EXCLUDED.role, position = EXCLUDED.position
+`
+	_, err := tx.Exec(ctx, query, songIDs, groupID)
+	return err
+}
+
+// renameCredits moves every credit of oldID on the given songs to newID,
+// including the songs' own group.
+func renameCredits(ctx context.Context, tx pgx.Tx, songIDs []uint64, oldID, newID uint64) error {
+	if oldID == newID {
+		return nil
+	}
+
+	moveQuery := `
+UPDATE song_artists sa SET group_id = $3
+WHERE sa.song_id = ANY($1) AND sa.group_id = $2
+  AND NOT EXISTS (SELECT 1 FROM song_artists x WHERE x.song_id = sa.song_id AND x.group_id = $3)
+`
+	// Songs already crediting newID keep that credit and lose the old one.
+	dropQuery := "DELETE FROM song_artists WHERE song_id = ANY($1) AND group_id = $2"
+	songsQuery := "UPDATE songs SET group_id = $3 WHERE id = ANY($1) AND group_id = $2"
+
+	for _, query := range []string{moveQuery, dropQuery, songsQuery} {
+		if _, err := tx.Exec(ctx, query, songIDs, oldID, newID); err != nil {
+			return err
+		}
+	}
+
+	// A song whose own group became newID may have credited it in another
+	// role before.
+	primaryQuery := `
+UPDATE song_artists sa SET role = 'primary', position = 0
+FROM songs s
+WHERE s.id = ANY($1) AND sa.song_id = s.id AND sa.group_id = s.group_id
+`
+	_, err := tx.Exec(ctx, primaryQuery, songIDs)
+	return err
+}
@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"song_lib/internal/domain/model"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+const groupColumns = "id, name, country, formed_year, description, created_at, updated_at"
+
+type Group struct {
+	pool *pgxpool.Pool
+	// replica serves the read methods. It is the primary pool when no
+	// replica is configured.
+	replica *pgxpool.Pool
+	log     *logrus.Logger
+}
+
+func NewGroup(pool, replica *pgxpool.Pool, log *logrus.Logger) *Group {
+	if replica == nil {
+		replica = pool
+	}
+
+	return &Group{
+		pool:    pool,
+		replica: replica,
+		log:     log,
+	}
+}
+
+func scanGroup(row pgx.Row, group *model.Group) error {
+	return row.Scan(
+		&group.ID,
+		&group.Name,
+		&group.Country,
+		&group.FormedYear,
+		&group.Description,
+		&group.CreatedAt,
+		&group.UpdatedAt,
+	)
+}
+
+func (g *Group) List(ctx context.Context, request model.GroupsRequest) ([]model.Group, error) {
+	log := g.log.WithContext(ctx).WithField("op", "internal/repository/group/List")
+
+	query := "SELECT " + groupColumns + " FROM groups ORDER BY name LIMIT $1 OFFSET $2"
+
+	log.Debugf("Executing query: %s with args: [%d, %d]", query, request.PerPage, request.Page*request.PerPage)
+
+	rows, err := g.replica.Query(ctx, query, request.PerPage, request.Page*request.PerPage)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := []model.Group{}
+	for rows.Next() {
+		group := model.Group{}
+		if err := scanGroup(rows, &group); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	log.Infof("Successfully retrieved %d groups", len(groups))
+	return groups, nil
+}
+
+func (g *Group) Get(ctx context.Context, id uint64) (model.Group, error) {
+	log := g.log.WithContext(ctx).WithField("op", "internal/repository/group/Get")
+
+	query := "SELECT " + groupColumns + " FROM groups WHERE id = $1"
+
+	var group model.Group
+	if err := scanGroup(g.replica.QueryRow(ctx, query, id), &group); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Group{}, fmt.Errorf("group %d: %w", id, model.ErrNotFound)
+		}
+		log.Error(err)
+		return model.Group{}, err
+	}
+
+	return group, nil
+}
+
+func (g *Group) Add(ctx context.Context, group model.AddGroup) (model.Group, error) {
+	log := g.log.WithContext(ctx).WithField("op", "internal/repository/group/Add")
+
+	log.Debugf("Received group to add: %+v", group)
+
+	query := "INSERT INTO groups (name, country, formed_year, description) VALUES ($1, $2, $3, $4) RETURNING " + groupColumns
+
+	var added model.Group
+	row := g.pool.QueryRow(ctx, query, group.Name, group.Country, group.FormedYear, group.Description)
+	if err := scanGroup(row, &added); err != nil {
+		if hasCode(err, uniqueViolation) {
+			return model.Group{}, fmt.Errorf("group %q already exists: %w", group.Name, model.ErrConflict)
+		}
+		log.Error(err)
+		return model.Group{}, err
+	}
+
+	log.Infof("Successfully added group with ID: %d", added.ID)
+	return added, nil
+}
+
+// Update changes the set fields. A rename also bumps the change sequence of
+// every song crediting the group so syncing clients pick up the new name.
+func (g *Group) Update(ctx context.Context, group model.UpdateGroup) (model.Group, error) {
+	log := g.log.WithContext(ctx).WithField("op", "internal/repository/group/Update")
+
+	log.Debugf("Received group to update: %+v", group)
+
+	query := "UPDATE groups SET"
+	var args []interface{}
+	argID := 1
+
+	if group.Name != "" {
+		query += fmt.Sprintf(" name = $%d,", argID)
+		argID++
+		args = append(args, group.Name)
+	}
+	if group.Country != "" {
+		query += fmt.Sprintf(" country = $%d,", argID)
+		argID++
+		args = append(args, group.Country)
+	}
+	if group.FormedYear != nil {
+		query += fmt.Sprintf(" formed_year = $%d,", argID)
+		argID++
+		args = append(args, *group.FormedYear)
+	}
+	if group.Description != "" {
+		query += fmt.Sprintf(" description = $%d,", argID)
+		argID++
+		args = append(args, group.Description)
+	}
+
+	query += fmt.Sprintf(" updated_at = now() WHERE id = $%d RETURNING %s", argID, groupColumns)
+	args = append(args, group.ID)
+
+	touchSongs := "UPDATE songs SET updated_at = now(), change_seq = nextval('song_change_seq'), change_xid = pg_current_xact_id() WHERE id IN (SELECT song_id FROM song_artists WHERE group_id = $1)"
+
+	log.Debugf("Executing query: %s", query)
+
+	var updated model.Group
+	err := pgx.BeginFunc(ctx, g.pool, func(tx pgx.Tx) error {
+		if err := scanGroup(tx.QueryRow(ctx, query, args...), &updated); err != nil {
+			return err
+		}
+		if group.Name == "" {
+			return nil
+		}
+
+		_, err := tx.Exec(ctx, touchSongs, group.ID)
+		return err
+	})
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return model.Group{}, fmt.Errorf("group %d: %w", group.ID, model.ErrNotFound)
+	case hasCode(err, uniqueViolation):
+		return model.Group{}, fmt.Errorf("group %q already exists: %w", group.Name, model.ErrConflict)
+	case err != nil:
+		log.Error(err)
+		return model.Group{}, err
+	}
+
+	log.Infof("Successfully updated group with ID: %d", updated.ID)
+	return updated, nil
+}
+
+// Delete removes a group that no song or album refers to any more.
+func (g *Group) Delete(ctx context.Context, id uint64) error {
+	log := g.log.WithContext(ctx).WithField("op", "internal/repository/group/Delete")
+
+	log.Infof("Attempting to delete group with ID: %d", id)
+
+	tag, err := g.pool.Exec(ctx, "DELETE FROM groups WHERE id = $1", id)
+	if err != nil {
+		if hasCode(err, foreignKeyViolation) {
+			return fmt.Errorf("group %d is still credited on songs or albums: %w", id, model.ErrConflict)
+		}
+		log.Error(err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("group %d: %w", id, model.ErrNotFound)
+	}
+
+	log.Infof("Successfully deleted group with ID: %d", id)
+	return nil
+}
+
+func (g *Group) GetSongs(ctx context.Context, request model.GroupSongsRequest) ([]model.Song, error) {
+	log := g.log.WithContext(ctx).WithField("op", "internal/repository/group/GetSongs")
+
+	query := `
+SELECT ` + songColumns + `
+FROM songs s
+JOIN groups g ON g.id = s.group_id
+WHERE s.id IN (SELECT song_id FROM song_artists WHERE group_id = $1)
+ORDER BY s.id
+LIMIT $2 OFFSET $3
+`
+
+	var exists bool
+	if err := g.replica.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM groups WHERE id = $1)", request.GroupID).Scan(&exists); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("group %d: %w", request.GroupID, model.ErrNotFound)
+	}
+
+	rows, err := g.replica.Query(ctx, query, request.GroupID, request.PerPage, request.Page*request.PerPage)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	songs := []model.Song{}
+	for rows.Next() {
+		song := model.Song{}
+		if err := scanSong(rows, &song); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		songs = append(songs, song)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	rows.Close()
+
+	if err := loadCredits(ctx, g.replica, songs); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	log.Infof("Successfully retrieved %d songs for group ID: %d", len(songs), request.GroupID)
+	return songs, nil
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type Health struct {
+	pool *pgxpool.Pool
+	log  *logrus.Logger
+}
+
+func NewHealth(pool *pgxpool.Pool, log *logrus.Logger) *Health {
+	return &Health{
+		pool: pool,
+		log:  log,
+	}
+}
+
+func (h *Health) Ping(ctx context.Context) error {
+	return h.pool.Ping(ctx)
+}
+
+func (h *Health) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	log := h.log.WithContext(ctx).WithField("op", "internal/repository/health/MigrationVersion")
+
+	query := "SELECT version, dirty FROM schema_migrations LIMIT 1"
+
+	var version int64
+	var dirty bool
+	if err := h.pool.QueryRow(ctx, query).Scan(&version, &dirty); err != nil {
+		log.Error(err)
+		return 0, false, err
+	}
+
+	return uint(version), dirty, nil
+}
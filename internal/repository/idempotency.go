@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"song_lib/internal/domain/model"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type Idempotency struct {
+	pool *pgxpool.Pool
+	log  *logrus.Logger
+}
+
+func NewIdempotency(pool *pgxpool.Pool, log *logrus.Logger) *Idempotency {
+	return &Idempotency{
+		pool: pool,
+		log:  log,
+	}
+}
+
+func (i *Idempotency) Reserve(ctx context.Context, record model.IdempotencyRecord, ttl time.Duration) (model.IdempotencyRecord, bool, error) {
+	log := i.log.WithContext(ctx).WithField("op", "internal/repository/idempotency/Reserve")
+
+	// An expired record is taken over as if it did not exist.
+	insert := `
+INSERT INTO idempotency_keys (scope, key, request_hash, expires_at)
+VALUES ($1, $2, $3, now() + make_interval(secs => $4))
+ON CONFLICT (scope, key) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    response_status = NULL,
+    response_content_type = NULL,
+    response_body = NULL,
+    created_at = now(),
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at < now()
+RETURNING key
+`
+	query := `
+SELECT request_hash, response_status, response_content_type, response_body
+FROM idempotency_keys
+WHERE scope = $1 AND key = $2
+`
+
+	// The existing record may be released between the two statements, so
+	// try once more before giving up.
+	for attempt := 0; attempt < 2; attempt++ {
+		var key string
+		err := i.pool.QueryRow(ctx, insert, record.Scope, record.Key, record.RequestHash, ttl.Seconds()).Scan(&key)
+		if err == nil {
+			log.Debugf("Reserved idempotency key %s", record.Key)
+			return model.IdempotencyRecord{}, true, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			log.Error(err)
+			return model.IdempotencyRecord{}, false, err
+		}
+
+		existing := model.IdempotencyRecord{Scope: record.Scope, Key: record.Key}
+		var status *int
+		var contentType *string
+		err = i.pool.QueryRow(ctx, query, record.Scope, record.Key).Scan(
+			&existing.RequestHash,
+			&status,
+			&contentType,
+			&existing.Body,
+		)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			log.Error(err)
+			return model.IdempotencyRecord{}, false, err
+		}
+
+		if status != nil {
+			existing.Status = *status
+		}
+		if contentType != nil {
+			existing.ContentType = *contentType
+		}
+
+		return existing, false, nil
+	}
+
+	err := errors.New("idempotency key changed concurrently")
+	log.Error(err)
+	return model.IdempotencyRecord{}, false, err
+}
+
+func (i *Idempotency) Complete(ctx context.Context, record model.IdempotencyRecord) error {
+	log := i.log.WithContext(ctx).WithField("op", "internal/repository/idempotency/Complete")
+
+	query := `
+UPDATE idempotency_keys
+SET response_status = $3, response_content_type = $4, response_body = $5
+WHERE scope = $1 AND key = $2
+`
+
+	if _, err := i.pool.Exec(ctx, query, record.Scope, record.Key, record.Status, record.ContentType, record.Body); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	log.Debugf("Stored response %d for idempotency key %s", record.Status, record.Key)
+	return nil
+}
+
+func (i *Idempotency) Release(ctx context.Context, scope, key string) error {
+	log := i.log.WithContext(ctx).WithField("op", "internal/repository/idempotency/Release")
+
+	query := "DELETE FROM idempotency_keys WHERE scope = $1 AND key = $2"
+
+	if _, err := i.pool.Exec(ctx, query, scope, key); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (i *Idempotency) DeleteExpired(ctx context.Context) (int64, error) {
+	log := i.log.WithContext(ctx).WithField("op", "internal/repository/idempotency/DeleteExpired")
+
+	query := "DELETE FROM idempotency_keys WHERE expires_at < now()"
+
+	tag, err := i.pool.Exec(ctx, query)
+	if err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,322 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"song_lib/internal/domain/model"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+const personColumns = "id, name, created_at, updated_at"
+
+type Person struct {
+	pool *pgxpool.Pool
+	// replica serves the read methods. It is the primary pool when no
+	// replica is configured.
+	replica *pgxpool.Pool
+	log     *logrus.Logger
+}
+
+func NewPerson(pool, replica *pgxpool.Pool, log *logrus.Logger) *Person {
+	if replica == nil {
+		replica = pool
+	}
+
+	return &Person{
+		pool:    pool,
+		replica: replica,
+		log:     log,
+	}
+}
+
+func scanPerson(row pgx.Row, person *model.Person) error {
+	return row.Scan(
+		&person.ID,
+		&person.Name,
+		&person.CreatedAt,
+		&person.UpdatedAt,
+	)
+}
+
+func (p *Person) List(ctx context.Context, request model.PeopleRequest) ([]model.Person, error) {
+	log := p.log.WithContext(ctx).WithField("op", "internal/repository/person/List")
+
+	query := "SELECT " + personColumns + " FROM people ORDER BY name LIMIT $1 OFFSET $2"
+
+	log.Debugf("Executing query: %s with args: [%d, %d]", query, request.PerPage, request.Page*request.PerPage)
+
+	rows, err := p.replica.Query(ctx, query, request.PerPage, request.Page*request.PerPage)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	people := []model.Person{}
+	for rows.Next() {
+		person := model.Person{}
+		if err := scanPerson(rows, &person); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		people = append(people, person)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	log.Infof("Successfully retrieved %d people", len(people))
+	return people, nil
+}
+
+func (p *Person) Get(ctx context.Context, id uint64) (model.Person, error) {
+	log := p.log.WithContext(ctx).WithField("op", "internal/repository/person/Get")
+
+	query := "SELECT " + personColumns + " FROM people WHERE id = $1"
+
+	var person model.Person
+	if err := scanPerson(p.replica.QueryRow(ctx, query, id), &person); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Person{}, fmt.Errorf("person %d: %w", id, model.ErrNotFound)
+		}
+		log.Error(err)
+		return model.Person{}, err
+	}
+
+	return person, nil
+}
+
+func (p *Person) Add(ctx context.Context, person model.AddPerson) (model.Person, error) {
+	log := p.log.WithContext(ctx).WithField("op", "internal/repository/person/Add")
+
+	log.Debugf("Received person to add: %+v", person)
+
+	query := "INSERT INTO people (name) VALUES ($1) RETURNING " + personColumns
+
+	var added model.Person
+	if err := scanPerson(p.pool.QueryRow(ctx, query, person.Name), &added); err != nil {
+		if hasCode(err, uniqueViolation) {
+			return model.Person{}, fmt.Errorf("person %q already exists: %w", person.Name, model.ErrConflict)
+		}
+		log.Error(err)
+		return model.Person{}, err
+	}
+
+	log.Infof("Successfully added person with ID: %d", added.ID)
+	return added, nil
+}
+
+func (p *Person) Update(ctx context.Context, person model.UpdatePerson) (model.Person, error) {
+	log := p.log.WithContext(ctx).WithField("op", "internal/repository/person/Update")
+
+	log.Debugf("Received person to update: %+v", person)
+
+	query := "UPDATE people SET name = $1, updated_at = now() WHERE id = $2 RETURNING " + personColumns
+
+	var updated model.Person
+	err := scanPerson(p.pool.QueryRow(ctx, query, person.Name, person.ID), &updated)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return model.Person{}, fmt.Errorf("person %d: %w", person.ID, model.ErrNotFound)
+	case hasCode(err, uniqueViolation):
+		return model.Person{}, fmt.Errorf("person %q already exists: %w", person.Name, model.ErrConflict)
+	case err != nil:
+		log.Error(err)
+		return model.Person{}, err
+	}
+
+	log.Infof("Successfully updated person with ID: %d", updated.ID)
+	return updated, nil
+}
+
+// Delete removes a person who is no longer credited on any song.
+func (p *Person) Delete(ctx context.Context, id uint64) error {
+	log := p.log.WithContext(ctx).WithField("op", "internal/repository/person/Delete")
+
+	log.Infof("Attempting to delete person with ID: %d", id)
+
+	tag, err := p.pool.Exec(ctx, "DELETE FROM people WHERE id = $1", id)
+	if err != nil {
+		if hasCode(err, foreignKeyViolation) {
+			return fmt.Errorf("person %d is still credited on songs: %w", id, model.ErrConflict)
+		}
+		log.Error(err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("person %d: %w", id, model.ErrNotFound)
+	}
+
+	log.Infof("Successfully deleted person with ID: %d", id)
+	return nil
+}
+
+func (p *Person) GetSongs(ctx context.Context, request model.PersonSongsRequest) ([]model.Song, error) {
+	log := p.log.WithContext(ctx).WithField("op", "internal/repository/person/GetSongs")
+
+	query := `
+SELECT ` + songColumns + `
+FROM songs s
+JOIN groups g ON g.id = s.group_id
+WHERE s.id IN (SELECT song_id FROM song_people WHERE person_id = $1 AND ($2 = '' OR role = $2))
+ORDER BY s.id
+LIMIT $3 OFFSET $4
+`
+
+	var exists bool
+	if err := p.replica.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM people WHERE id = $1)", request.PersonID).Scan(&exists); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("person %d: %w", request.PersonID, model.ErrNotFound)
+	}
+
+	rows, err := p.replica.Query(ctx, query, request.PersonID, request.Role, request.PerPage, request.Page*request.PerPage)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	songs := []model.Song{}
+	for rows.Next() {
+		song := model.Song{}
+		if err := scanSong(rows, &song); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		songs = append(songs, song)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	rows.Close()
+
+	if err := loadCredits(ctx, p.replica, songs); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	log.Infof("Successfully retrieved %d songs for person ID: %d", len(songs), request.PersonID)
+	return songs, nil
+}
+
+func (p *Person) GetCredits(ctx context.Context, songID uint64) ([]model.PersonCredit, error) {
+	log := p.log.WithContext(ctx).WithField("op", "internal/repository/person/GetCredits")
+
+	credits, err := songPeople(ctx, p.replica, songID)
+	if err != nil {
+		if !errors.Is(err, model.ErrNotFound) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	return credits, nil
+}
+
+// SetCredits replaces the person credits of a song, creating people named
+// for the first time.
+func (p *Person) SetCredits(ctx context.Context, request model.SetSongCredits) ([]model.PersonCredit, error) {
+	log := p.log.WithContext(ctx).WithField("op", "internal/repository/person/SetCredits")
+
+	log.Debugf("Setting %d credits on song ID: %d", len(request.Credits), request.SongID)
+
+	ensurePerson := `
+INSERT INTO people (name) VALUES ($1)
+ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+RETURNING id
+`
+	insertCredit := `
+INSERT INTO song_people (song_id, person_id, role, position) VALUES ($1, $2, $3, $4)
+ON CONFLICT (song_id, person_id, role) DO NOTHING
+`
+
+	var credits []model.PersonCredit
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		if err := lockSong(ctx, tx, request.SongID); err != nil {
+			return err
+		}
+		songID := request.SongID
+
+		if _, err := tx.Exec(ctx, "DELETE FROM song_people WHERE song_id = $1", songID); err != nil {
+			return err
+		}
+
+		for i, credit := range request.Credits {
+			var personID uint64
+			if err := tx.QueryRow(ctx, ensurePerson, credit.Name).Scan(&personID); err != nil {
+				return err
+			}
+			if _, err := tx.Exec(ctx, insertCredit, songID, personID, credit.Role, i); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		credits, err = songPeople(ctx, tx, songID)
+		return err
+	})
+	if err != nil {
+		if !errors.Is(err, model.ErrNotFound) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	log.Infof("Successfully set %d credits on song ID: %d", len(credits), request.SongID)
+	return credits, nil
+}
+
+// songPeople reads the person credits of a song in the order they were set.
+func songPeople(ctx context.Context, q querier, songID uint64) ([]model.PersonCredit, error) {
+	query := `
+SELECT sp.person_id, p.name, sp.role
+FROM songs s
+LEFT JOIN song_people sp ON sp.song_id = s.id
+LEFT JOIN people p ON p.id = sp.person_id
+WHERE s.id = $1
+ORDER BY sp.position, sp.role
+`
+
+	rows, err := q.Query(ctx, query, songID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := false
+	credits := []model.PersonCredit{}
+	for rows.Next() {
+		found = true
+
+		var personID *uint64
+		var name, role *string
+		if err := rows.Scan(&personID, &name, &role); err != nil {
+			return nil, err
+		}
+		// A song without credits comes back as a single row of NULLs.
+		if personID == nil {
+			continue
+		}
+		credits = append(credits, model.PersonCredit{PersonID: *personID, Name: *name, Role: *role})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("song %d: %w", songID, model.ErrNotFound)
+	}
+
+	return credits, nil
+}
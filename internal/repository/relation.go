@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"song_lib/internal/domain/model"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type Relation struct {
+	pool *pgxpool.Pool
+	// replica serves the read methods. It is the primary pool when no
+	// replica is configured.
+	replica *pgxpool.Pool
+	log     *logrus.Logger
+}
+
+func NewRelation(pool, replica *pgxpool.Pool, log *logrus.Logger) *Relation {
+	if replica == nil {
+		replica = pool
+	}
+
+	return &Relation{
+		pool:    pool,
+		replica: replica,
+		log:     log,
+	}
+}
+
+func (r *Relation) List(ctx context.Context, request model.SongRelationsRequest) ([]model.SongRelation, error) {
+	log := r.log.WithContext(ctx).WithField("op", "internal/repository/relation/List")
+
+	log.Debugf("Received request: %+v", request)
+
+	var exists bool
+	if err := r.replica.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM songs WHERE id = $1)", request.SongID).Scan(&exists); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("song %d: %w", request.SongID, model.ErrNotFound)
+	}
+
+	relations, err := songRelations(ctx, r.replica, request)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	log.Infof("Successfully retrieved %d relations for song ID: %d", len(relations), request.SongID)
+	return relations, nil
+}
+
+func (r *Relation) Add(ctx context.Context, request model.AddSongRelation) (model.SongRelation, error) {
+	log := r.log.WithContext(ctx).WithField("op", "internal/repository/relation/Add")
+
+	log.Debugf("Received relation to add: %+v", request)
+
+	query := `
+WITH added AS (
+    INSERT INTO song_relations (song_id, related_song_id, type) VALUES ($1, $2, $3)
+    RETURNING related_song_id, type
+)
+SELECT added.type, s.id, s.song, g.name
+FROM added
+JOIN songs s ON s.id = added.related_song_id
+JOIN groups g ON g.id = s.group_id
+`
+
+	relation := model.SongRelation{Direction: model.RelationOutgoing, Depth: 1}
+	row := r.pool.QueryRow(ctx, query, request.SongID, request.RelatedSongID, request.Type)
+	err := row.Scan(&relation.Type, &relation.SongID, &relation.Song, &relation.Group)
+	switch {
+	case hasCode(err, foreignKeyViolation):
+		return model.SongRelation{}, fmt.Errorf("song %d or %d: %w", request.SongID, request.RelatedSongID, model.ErrNotFound)
+	case hasCode(err, uniqueViolation):
+		return model.SongRelation{}, fmt.Errorf("song %d is already %s song %d: %w", request.SongID, request.Type, request.RelatedSongID, model.ErrConflict)
+	case err != nil:
+		log.Error(err)
+		return model.SongRelation{}, err
+	}
+
+	log.Infof("Song ID %d is now %s song ID %d", request.SongID, request.Type, request.RelatedSongID)
+	return relation, nil
+}
+
+func (r *Relation) Delete(ctx context.Context, request model.DeleteSongRelation) error {
+	log := r.log.WithContext(ctx).WithField("op", "internal/repository/relation/Delete")
+
+	log.Debugf("Received relation to delete: %+v", request)
+
+	query := "DELETE FROM song_relations WHERE song_id = $1 AND related_song_id = $2 AND type = $3"
+
+	tag, err := r.pool.Exec(ctx, query, request.SongID, request.RelatedSongID, request.Type)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("song %d is not %s song %d: %w", request.SongID, request.Type, request.RelatedSongID, model.ErrNotFound)
+	}
+
+	log.Infof("Song ID %d is no longer %s song ID %d", request.SongID, request.Type, request.RelatedSongID)
+	return nil
+}
+
+// relationEnds maps a direction to the column a relation is followed from
+// and the column it leads to.
+var relationEnds = map[string][2]string{
+	model.RelationOutgoing: {"song_id", "related_song_id"},
+	model.RelationIncoming: {"related_song_id", "song_id"},
+}
+
+// songRelations walks the relations of a song up to request.Depth steps in
+// the requested directions. Each related song is reported once per type, at
+// the shortest depth it was reached.
+func songRelations(ctx context.Context, q querier, request model.SongRelationsRequest) ([]model.SongRelation, error) {
+	directions := []string{model.RelationOutgoing, model.RelationIncoming}
+	if request.Direction != "" {
+		directions = []string{request.Direction}
+	}
+
+	relations := []model.SongRelation{}
+	for _, direction := range directions {
+		ends := relationEnds[direction]
+		query := fmt.Sprintf(`
+WITH RECURSIVE walk (type, song_id, depth, path) AS (
+    SELECT r.type, r.%[2]s, 1, ARRAY[r.%[1]s, r.%[2]s]
+    FROM song_relations r
+    WHERE r.%[1]s = $1 AND ($2 = '' OR r.type = $2)
+  UNION ALL
+    SELECT r.type, r.%[2]s, w.depth + 1, w.path || r.%[2]s
+    FROM walk w
+    JOIN song_relations r ON r.%[1]s = w.song_id
+    WHERE w.depth < $3 AND ($2 = '' OR r.type = $2) AND r.%[2]s <> ALL (w.path)
+)
+SELECT type, depth, id, song, name
+FROM (
+    SELECT DISTINCT ON (w.song_id, w.type) w.type, w.depth, s.id, s.song, g.name
+    FROM walk w
+    JOIN songs s ON s.id = w.song_id
+    JOIN groups g ON g.id = s.group_id
+    ORDER BY w.song_id, w.type, w.depth
+) nearest
+ORDER BY depth, type, id
+`, ends[0], ends[1])
+
+		rows, err := q.Query(ctx, query, request.SongID, request.Type, request.Depth)
+		if err != nil {
+			return nil, err
+		}
+		found, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.SongRelation, error) {
+			relation := model.SongRelation{Direction: direction}
+			err := row.Scan(&relation.Type, &relation.Depth, &relation.SongID, &relation.Song, &relation.Group)
+			return relation, err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		relations = append(relations, found...)
+	}
+
+	return relations, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"song_lib/internal/domain/repository"
+	"song_lib/internal/tracing"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sirupsen/logrus"
@@ -9,10 +10,25 @@ import (
 
 type Repositories struct {
 	repository.Song
+	repository.Group
+	repository.Album
+	repository.Person
+	repository.Tag
+	repository.Relation
+	repository.Health
+	repository.Idempotency
 }
 
-func NewRepositories(pool *pgxpool.Pool, log *logrus.Logger) *Repositories {
+// NewRepositories wires the repositories. replica may be nil.
+func NewRepositories(pool, replica *pgxpool.Pool, log *logrus.Logger, tracer *tracing.Tracer) *Repositories {
 	return &Repositories{
-		Song: NewSong(pool, log),
+		Song:        newSongTracing(NewSong(pool, replica, log), tracer),
+		Group:       newGroupTracing(NewGroup(pool, replica, log), tracer),
+		Album:       newAlbumTracing(NewAlbum(pool, replica, log), tracer),
+		Person:      newPersonTracing(NewPerson(pool, replica, log), tracer),
+		Tag:         newTagTracing(NewTag(pool, replica, log), tracer),
+		Relation:    newRelationTracing(NewRelation(pool, replica, log), tracer),
+		Health:      NewHealth(pool, log),
+		Idempotency: NewIdempotency(pool, log),
 	}
 }
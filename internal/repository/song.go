@@ -2,46 +2,46 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"song_lib/internal/domain/model"
+	"strconv"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sirupsen/logrus"
 )
 
 type Song struct {
 	pool *pgxpool.Pool
-	log  *logrus.Logger
+	// replica serves GetSongs, Get and GetVerses. It is the primary pool when
+	// no replica is configured. GetChanges stays on the primary so sync tokens
+	// never run ahead of what the client can read back.
+	replica *pgxpool.Pool
+	log     *logrus.Logger
 }
 
-func NewSong(pool *pgxpool.Pool, log *logrus.Logger) *Song {
+func NewSong(pool, replica *pgxpool.Pool, log *logrus.Logger) *Song {
+	if replica == nil {
+		replica = pool
+	}
+
 	return &Song{
-		pool: pool,
-		log:  log,
+		pool:    pool,
+		replica: replica,
+		log:     log,
 	}
 }
 
 func (s *Song) GetSongs(ctx context.Context, filter model.LibraryFilter) ([]model.SongDetails, error) {
-	log := s.log.WithField("op", "internal/repository/song/GetSongs")
+	log := s.log.WithContext(ctx).WithField("op", "internal/repository/song/GetSongs")
 
 	log.Debugf("Received filter: %+v", filter)
 
-	query := "SELECT release_date, text, link FROM songs WHERE 1=1"
-	var args []interface{}
-	argID := 1
-
-	if filter.Group != "" {
-		query += fmt.Sprintf(" AND group_name = $%d", argID)
-		argID++
-		args = append(args, filter.Group)
-		log.Debugf("Added group filter: %s", filter.Group)
-	}
-	if filter.Song != "" {
-		query += fmt.Sprintf(" AND song = $%d", argID)
-		argID++
-		args = append(args, filter.Song)
-		log.Debugf("Added song filter: %s", filter.Song)
-	}
+	conditions, args := filterConditions(filter, nil)
+	query := "SELECT id, release_date, text, link FROM songs WHERE 1=1" + conditions
+	argID := len(args) + 1
 
 	query += fmt.Sprintf(" LIMIT $%d", argID)
 	argID++
@@ -50,48 +50,65 @@ func (s *Song) GetSongs(ctx context.Context, filter model.LibraryFilter) ([]mode
 	query += fmt.Sprintf(" OFFSET $%d", argID)
 	args = append(args, filter.PerPage*filter.Page)
 
-	log.Debugf("Executing query: %s with args: %+v", query, args)
-
-	rows, err := s.pool.Query(ctx, query, args...)
-	if err != nil {
-		log.Error(err)
-		return nil, err
-	}
-	defer rows.Close()
+	log.Debugf("Executing query: %s with %d args", query, len(args))
 
+	// The page and its credits come from one snapshot.
 	var songs []model.SongDetails
-	for rows.Next() {
-		s := model.SongDetails{}
+	txOptions := pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+	err := pgx.BeginTxFunc(ctx, s.replica, txOptions, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		songs, err = pgx.AppendRows(songs, rows, func(row pgx.CollectableRow) (model.SongDetails, error) {
+			s := model.SongDetails{}
+			err := row.Scan(
+				&s.ID,
+				&s.ReleaseDate,
+				&s.Text,
+				&s.Link,
+			)
+			return s, err
+		})
+		if err != nil {
+			return err
+		}
 
-		err := rows.Scan(
-			&s.ReleaseDate,
-			&s.Text,
-			&s.Link,
-		)
+		ids := make([]uint64, len(songs))
+		for i := range songs {
+			ids[i] = songs[i].ID
+		}
+		credits, err := creditsBySong(ctx, tx, ids)
 		if err != nil {
-			log.Error(err)
-			return nil, err
+			return err
+		}
+		for i := range songs {
+			songs[i].Artists = credits[songs[i].ID]
 		}
 
-		songs = append(songs, s)
+		return nil
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
 	}
 
 	log.Infof("Successfully retrieved %d songs", len(songs))
 	return songs, nil
 }
 
-func (s *Song) GetVerses(ctx context.Context, filter model.VersesRequest) ([]string, error) {
-	log := s.log.WithField("op", "internal/repository/song/GetVerses")
+func (s *Song) GetVerses(ctx context.Context, filter model.VersesRequest) ([]string, time.Time, error) {
+	log := s.log.WithContext(ctx).WithField("op", "internal/repository/song/GetVerses")
 
 	log.Debugf("Received filter: %+v", filter)
 
 	query := `
 WITH split_songs AS (
-    SELECT unnest(string_to_array(text, E'\n\n')) AS verse
+    SELECT unnest(string_to_array(text, E'\n\n')) AS verse, updated_at
     FROM songs
     WHERE id = $1
 )
-SELECT verse
+SELECT verse, updated_at
 FROM split_songs
 ORDER BY verse
 LIMIT $2 OFFSET $3;
@@ -99,20 +116,21 @@ LIMIT $2 OFFSET $3;
 
 	log.Debugf("Executing query: %s with args: [%d, %d, %d]", query, filter.SongID, filter.PerPage, filter.Page*filter.PerPage)
 
-	rows, err := s.pool.Query(ctx, query, filter.SongID, filter.PerPage, filter.Page*filter.PerPage)
+	rows, err := s.replica.Query(ctx, query, filter.SongID, filter.PerPage, filter.Page*filter.PerPage)
 	if err != nil {
 		log.Error(err)
-		return nil, err
+		return nil, time.Time{}, err
 	}
 	defer rows.Close()
 
 	var verses []string
+	var updatedAt time.Time
 	for rows.Next() {
 		var verse string
 
-		if err := rows.Scan(&verse); err != nil {
+		if err := rows.Scan(&verse, &updatedAt); err != nil {
 			log.Error(err)
-			return nil, err
+			return nil, time.Time{}, err
 		}
 
 		verses = append(verses, verse)
@@ -120,34 +138,85 @@ LIMIT $2 OFFSET $3;
 
 	if err := rows.Err(); err != nil {
 		log.Error(err)
-		return nil, err
+		return nil, time.Time{}, err
 	}
 
 	log.Infof("Successfully retrieved %d verses for song ID: %d", len(verses), filter.SongID)
-	return verses, nil
+	return verses, updatedAt, nil
+}
+
+// Get returns a song with its credits and its direct relations in both
+// directions.
+func (s *Song) Get(ctx context.Context, id uint64) (model.Song, error) {
+	log := s.log.WithContext(ctx).WithField("op", "internal/repository/song/Get")
+
+	query := "SELECT " + songColumns + " FROM songs s JOIN groups g ON g.id = s.group_id WHERE s.id = $1"
+
+	// The song, its credits and its relations come from one snapshot.
+	var song model.Song
+	txOptions := pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+	err := pgx.BeginTxFunc(ctx, s.replica, txOptions, func(tx pgx.Tx) error {
+		if err := scanSong(tx.QueryRow(ctx, query, id), &song); err != nil {
+			return err
+		}
+
+		songs := []model.Song{song}
+		if err := loadCredits(ctx, tx, songs); err != nil {
+			return err
+		}
+		song = songs[0]
+
+		relations, err := songRelations(ctx, tx, model.SongRelationsRequest{SongID: id, Depth: 1})
+		if err != nil {
+			return err
+		}
+		song.Relations = relations
+
+		return nil
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Song{}, fmt.Errorf("song %d: %w", id, model.ErrNotFound)
+	}
+	if err != nil {
+		log.Error(err)
+		return model.Song{}, err
+	}
+
+	return song, nil
 }
 
 func (s *Song) Add(ctx context.Context, song model.Song) (uint64, error) {
-	log := s.log.WithField("op", "internal/repository/song/Add")
+	log := s.log.WithContext(ctx).WithField("op", "internal/repository/song/Add")
 
 	log.Debugf("Received song to add: %+v", song)
 
-	query := "INSERT INTO songs (song, group_name, release_date, link, text) VALUES ($1, $2, $3, $4, $5) RETURNING id"
+	query := "INSERT INTO songs (song, group_id, release_date, link, text) VALUES ($1, $2, $3, $4, $5) RETURNING id"
 
 	log.Debugf("Executing query: %s", query)
 
-	row := s.pool.QueryRow(
-		ctx,
-		query,
-		song.Song,
-		song.Group,
-		song.ReleaseDate,
-		song.Link,
-		song.Text,
-	)
-
 	var id uint64
-	if err := row.Scan(&id); err != nil {
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		groupID, err := ensureGroup(ctx, tx, song.Group)
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(
+			ctx,
+			query,
+			song.Song,
+			groupID,
+			song.ReleaseDate,
+			song.Link,
+			song.Text,
+		).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		return replaceCredits(ctx, tx, id, groupID, song.Artists)
+	})
+	if err != nil {
 		log.Error(err)
 		return 0, err
 	}
@@ -157,13 +226,27 @@ func (s *Song) Add(ctx context.Context, song model.Song) (uint64, error) {
 }
 
 func (s *Song) Delete(ctx context.Context, id uint64) error {
-	log := s.log.WithField("op", "internal/repository/song/Delete")
+	log := s.log.WithContext(ctx).WithField("op", "internal/repository/song/Delete")
 
 	log.Infof("Attempting to delete song with ID: %d", id)
 
 	query := "DELETE FROM songs WHERE id=$1"
 
-	_, err := s.pool.Exec(ctx, query, id)
+	// The tombstone lets syncing clients learn about the deletion.
+	tombstone := "INSERT INTO song_tombstones (song_id) VALUES ($1) ON CONFLICT (song_id) DO NOTHING"
+
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, query, id)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+
+		_, err = tx.Exec(ctx, tombstone, id)
+		return err
+	})
 	if err != nil {
 		log.Error(err)
 		return err
@@ -174,7 +257,7 @@ func (s *Song) Delete(ctx context.Context, id uint64) error {
 }
 
 func (s *Song) Update(ctx context.Context, song model.Song) (model.Song, error) {
-	log := s.log.WithField("op", "internal/repository/song/Update")
+	log := s.log.WithContext(ctx).WithField("op", "internal/repository/song/Update")
 
 	log.Debugf("Received song to update: %+v", song)
 
@@ -182,10 +265,13 @@ func (s *Song) Update(ctx context.Context, song model.Song) (model.Song, error)
 	var args []interface{}
 	argID := 1
 
+	// The group id is filled in inside the transaction below.
+	groupArg := -1
 	if song.Group != "" {
-		query += fmt.Sprintf(" group_name = $%d,", argID)
+		query += fmt.Sprintf(" group_id = $%d,", argID)
+		groupArg = len(args)
 		argID++
-		args = append(args, song.Group)
+		args = append(args, nil)
 	}
 
 	if song.Song != "" {
@@ -210,24 +296,56 @@ func (s *Song) Update(ctx context.Context, song model.Song) (model.Song, error)
 		args = append(args, song.Text)
 	}
 
+	query += " updated_at = now(), change_seq = nextval('song_change_seq'), change_xid = pg_current_xact_id(),"
+
 	query = query[:len(query)-1]
 
-	query += fmt.Sprintf(" WHERE id = $%d RETURNING id, song, group_name, release_date, link, text", argID)
+	query += fmt.Sprintf(" WHERE id = $%d RETURNING id, song, (SELECT name FROM groups WHERE groups.id = songs.group_id), group_id, release_date, link, text, created_at, updated_at", argID)
 	args = append(args, song.ID)
 
-	log.Debugf("Executing query: %s with args: %+v", query, args)
+	log.Debugf("Executing query: %s", query)
 
-	row := s.pool.QueryRow(ctx, query, args...)
+	// Without new credits, a group change only swaps the primary credit.
+	swapMainCredit := groupArg >= 0 && song.Artists == nil
 
 	var updatedSong model.Song
-	if err := row.Scan(
-		&updatedSong.ID,
-		&updatedSong.Song,
-		&updatedSong.Group,
-		&updatedSong.ReleaseDate,
-		&updatedSong.Link,
-		&updatedSong.Text,
-	); err != nil {
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if groupArg >= 0 {
+			groupID, err := ensureGroup(ctx, tx, song.Group)
+			if err != nil {
+				return err
+			}
+			args[groupArg] = groupID
+		}
+		if swapMainCredit {
+			if err := dropMainCredits(ctx, tx, []uint64{song.ID}); err != nil {
+				return err
+			}
+		}
+
+		if err := scanSong(tx.QueryRow(ctx, query, args...), &updatedSong); err != nil {
+			return err
+		}
+
+		switch {
+		case song.Artists != nil:
+			if err := replaceCredits(ctx, tx, updatedSong.ID, updatedSong.GroupID, song.Artists); err != nil {
+				return err
+			}
+		case swapMainCredit:
+			if err := addMainCredits(ctx, tx, []uint64{updatedSong.ID}, updatedSong.GroupID); err != nil {
+				return err
+			}
+		}
+
+		songs := []model.Song{updatedSong}
+		if err := loadCredits(ctx, tx, songs); err != nil {
+			return err
+		}
+		updatedSong = songs[0]
+		return nil
+	})
+	if err != nil {
 		log.Error(err)
 		return model.Song{}, err
 	}
@@ -235,3 +353,363 @@ func (s *Song) Update(ctx context.Context, song model.Song) (model.Song, error)
 	log.Infof("Successfully updated song with ID: %d", updatedSong.ID)
 	return updatedSong, nil
 }
+
+// GetChanges returns the changes after since in feed order. Only changes
+// written by transactions older than every transaction still running are
+// returned: their xids and sequence numbers are final, so a cursor taken
+// from them never skips a change that commits later. A long-running
+// transaction anywhere in the cluster holds the feed back until it ends.
+func (s *Song) GetChanges(ctx context.Context, since model.ChangeCursor, limit int) (model.ChangeSet, error) {
+	log := s.log.WithContext(ctx).WithField("op", "internal/repository/song/GetChanges")
+
+	log.Debugf("Fetching up to %d changes after %+v", limit, since)
+
+	// xid8 has no binary codec in pgx, so it travels as text.
+	upsertsQuery := `
+SELECT s.change_xid::text::bigint, s.change_seq, ` + songColumns + `
+FROM songs s
+JOIN groups g ON g.id = s.group_id
+WHERE (s.change_xid, s.change_seq) > ($1::text::xid8, $2)
+  AND s.change_xid < pg_snapshot_xmin(pg_current_snapshot())
+ORDER BY s.change_xid, s.change_seq
+LIMIT $3
+`
+	deletionsQuery := `
+SELECT change_xid::text::bigint, change_seq, song_id, deleted_at
+FROM song_tombstones
+WHERE (change_xid, change_seq) > ($1::text::xid8, $2)
+  AND change_xid < pg_snapshot_xmin(pg_current_snapshot())
+ORDER BY change_xid, change_seq
+LIMIT $3
+`
+
+	sinceXID := strconv.FormatUint(since.XID, 10)
+
+	// Fetch one extra row per source to know whether more changes follow.
+	var upserts []songChange
+	var deletions []tombstoneChange
+	var set model.ChangeSet
+
+	// Both sources, the xmin bound and the credits come from one snapshot.
+	txOptions := pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+	err := pgx.BeginTxFunc(ctx, s.pool, txOptions, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, upsertsQuery, sinceXID, since.Seq, limit+1)
+		if err != nil {
+			return err
+		}
+		upserts, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (songChange, error) {
+			c := songChange{}
+			err := row.Scan(&c.cursor.XID, &c.cursor.Seq, &c.song.ID, &c.song.Song, &c.song.Group, &c.song.GroupID, &c.song.ReleaseDate, &c.song.Link, &c.song.Text, &c.song.CreatedAt, &c.song.UpdatedAt)
+			return c, err
+		})
+		if err != nil {
+			return err
+		}
+
+		rows, err = tx.Query(ctx, deletionsQuery, sinceXID, since.Seq, limit+1)
+		if err != nil {
+			return err
+		}
+		deletions, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (tombstoneChange, error) {
+			c := tombstoneChange{}
+			err := row.Scan(&c.cursor.XID, &c.cursor.Seq, &c.tombstone.ID, &c.tombstone.DeletedAt)
+			return c, err
+		})
+		if err != nil {
+			return err
+		}
+
+		set = mergeChanges(since, limit, upserts, deletions)
+		return loadCredits(ctx, tx, set.Upserts)
+	})
+	if err != nil {
+		log.Error(err)
+		return model.ChangeSet{}, err
+	}
+
+	log.Infof("Successfully retrieved %d upserts and %d deletions", len(set.Upserts), len(set.Deletions))
+	return set, nil
+}
+
+type songChange struct {
+	cursor model.ChangeCursor
+	song   model.Song
+}
+
+type tombstoneChange struct {
+	cursor    model.ChangeCursor
+	tombstone model.SongTombstone
+}
+
+// mergeChanges interleaves upserts and deletions, each already in feed order,
+// and keeps the first limit of them. HasMore is set when anything is cut off.
+func mergeChanges(since model.ChangeCursor, limit int, upserts []songChange, deletions []tombstoneChange) model.ChangeSet {
+	set := model.ChangeSet{
+		Upserts:   []model.Song{},
+		Deletions: []model.SongTombstone{},
+		Last:      since,
+	}
+
+	i, j := 0, 0
+	for n := 0; i < len(upserts) || j < len(deletions); n++ {
+		if n == limit {
+			set.HasMore = true
+			break
+		}
+
+		if j == len(deletions) || (i < len(upserts) && upserts[i].cursor.Less(deletions[j].cursor)) {
+			set.Upserts = append(set.Upserts, upserts[i].song)
+			set.Last = upserts[i].cursor
+			i++
+		} else {
+			set.Deletions = append(set.Deletions, deletions[j].tombstone)
+			set.Last = deletions[j].cursor
+			j++
+		}
+	}
+
+	return set
+}
+
+// filterConditions renders the LibraryFilter match as " AND ..." clauses on
+// the songs table, appending their arguments to args. Pagination is left to
+// the caller.
+func filterConditions(filter model.LibraryFilter, args []interface{}) (string, []interface{}) {
+	conditions := ""
+
+	if filter.Group != "" {
+		args = append(args, filter.Group)
+		conditions += fmt.Sprintf(" AND id IN (SELECT sa.song_id FROM song_artists sa JOIN groups g ON g.id = sa.group_id WHERE g.name = $%d)", len(args))
+	}
+	if filter.Song != "" {
+		args = append(args, filter.Song)
+		conditions += fmt.Sprintf(" AND song = $%d", len(args))
+	}
+	if filter.Album != "" {
+		args = append(args, filter.Album)
+		conditions += fmt.Sprintf(" AND id IN (SELECT t.song_id FROM album_tracks t JOIN albums a ON a.id = t.album_id WHERE a.title = $%d)", len(args))
+	}
+
+	people := filter.People()
+	for _, role := range model.PersonRoles {
+		name, ok := people[role]
+		if !ok {
+			continue
+		}
+		args = append(args, role, name)
+		conditions += fmt.Sprintf(" AND id IN (SELECT sp.song_id FROM song_people sp JOIN people p ON p.id = sp.person_id WHERE sp.role = $%d AND p.name = $%d)", len(args)-1, len(args))
+	}
+
+	// Tags are expected to be distinct, so matching all of them means
+	// matching as many as were asked for.
+	if len(filter.Tags) > 0 {
+		args = append(args, filter.Tags)
+		tagged := fmt.Sprintf("SELECT st.song_id FROM song_tags st JOIN tags t ON t.id = st.tag_id WHERE t.name = ANY($%d)", len(args))
+		if filter.TagsMatch != model.TagsMatchAny {
+			args = append(args, len(filter.Tags))
+			tagged += fmt.Sprintf(" GROUP BY st.song_id HAVING count(*) = $%d", len(args))
+		}
+		conditions += " AND id IN (" + tagged + ")"
+	}
+
+	return conditions, args
+}
+
+// bulkColumns maps the field names accepted by set-field to columns.
+var bulkColumns = map[string]string{
+	"song":        "song",
+	"group":       "group_id",
+	"releaseDate": "release_date",
+	"link":        "link",
+	"text":        "text",
+}
+
+// Bulk locks every song matching the filter and applies the operation to all
+// of them in one transaction. Operations over the limit only report the
+// matching ids with model.ErrBulkLimitExceeded. Dry runs report them too,
+// whatever the limit, from a read-only transaction that locks nothing.
+func (s *Song) Bulk(ctx context.Context, op model.BulkOperation) (model.BulkResult, error) {
+	log := s.log.WithContext(ctx).WithField("op", "internal/repository/song/Bulk")
+
+	log.Debugf("Received bulk operation: %+v", op)
+
+	result := model.BulkResult{
+		Operation: op.Operation,
+		DryRun:    op.DryRun,
+		IDs:       []uint64{},
+	}
+
+	if op.DryRun {
+		err := s.previewBulk(ctx, op, &result)
+		if err != nil {
+			log.Error(err)
+			return model.BulkResult{}, err
+		}
+
+		log.Infof("Bulk %s dry run matched %d songs", op.Operation, result.Matched)
+		return result, nil
+	}
+
+	conditions, args := filterConditions(op.Filter, nil)
+	selectQuery := "SELECT id FROM songs WHERE 1=1" + conditions + " ORDER BY id FOR UPDATE"
+
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, selectQuery, args...)
+		if err != nil {
+			return err
+		}
+		ids, err := pgx.CollectRows(rows, pgx.RowTo[uint64])
+		if err != nil {
+			return err
+		}
+
+		result.IDs = append(result.IDs, reportedIDs(ids, op.MaxReportedIDs)...)
+		result.Matched = len(ids)
+
+		if len(ids) == 0 {
+			return nil
+		}
+		if op.MaxAffected > 0 && len(ids) > op.MaxAffected {
+			return model.ErrBulkLimitExceeded
+		}
+
+		switch op.Operation {
+		case model.BulkRenameGroup:
+			// Only the renamed group's credits move; other artists on the
+			// matched songs keep theirs.
+			var oldID uint64
+			if err := tx.QueryRow(ctx, "SELECT id FROM groups WHERE name = $1", op.Filter.Group).Scan(&oldID); err != nil {
+				return err
+			}
+			newID, err := ensureGroup(ctx, tx, op.Value)
+			if err != nil {
+				return err
+			}
+			if err := renameCredits(ctx, tx, ids, oldID, newID); err != nil {
+				return err
+			}
+
+			_, err = tx.Exec(ctx, "UPDATE songs SET updated_at = now(), change_seq = nextval('song_change_seq'), change_xid = pg_current_xact_id() WHERE id = ANY($1)", ids)
+			if err != nil {
+				return err
+			}
+		case model.BulkDelete:
+			if _, err := tx.Exec(ctx, "DELETE FROM songs WHERE id = ANY($1)", ids); err != nil {
+				return err
+			}
+			// Tombstones let syncing clients learn about the deletions.
+			_, err = tx.Exec(ctx, "INSERT INTO song_tombstones (song_id) SELECT unnest($1::bigint[]) ON CONFLICT (song_id) DO NOTHING", ids)
+			if err != nil {
+				return err
+			}
+		default:
+			column, ok := bulkColumns[op.Field]
+			if !ok {
+				return fmt.Errorf("unsupported bulk field %q", op.Field)
+			}
+
+			var value any = op.Value
+			if op.Field == "group" {
+				groupID, err := ensureGroup(ctx, tx, op.Value)
+				if err != nil {
+					return err
+				}
+				value = groupID
+
+				if err := dropMainCredits(ctx, tx, ids); err != nil {
+					return err
+				}
+			}
+
+			query := fmt.Sprintf("UPDATE songs SET %s = $1, updated_at = now(), change_seq = nextval('song_change_seq'), change_xid = pg_current_xact_id() WHERE id = ANY($2)", column)
+			if _, err := tx.Exec(ctx, query, value, ids); err != nil {
+				return err
+			}
+
+			if op.Field == "group" {
+				if err := addMainCredits(ctx, tx, ids, value.(uint64)); err != nil {
+					return err
+				}
+			}
+		}
+
+		result.Affected = len(ids)
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrBulkLimitExceeded) {
+			log.Warnf("Bulk %s matched %d songs, limit is %d", op.Operation, result.Matched, op.MaxAffected)
+			return result, err
+		}
+		log.Error(err)
+		return model.BulkResult{}, err
+	}
+
+	log.Infof("Bulk %s matched %d songs and changed %d", op.Operation, result.Matched, result.Affected)
+	return result, nil
+}
+
+// previewBulk fills in the songs a bulk operation would match without
+// locking them.
+func (s *Song) previewBulk(ctx context.Context, op model.BulkOperation, result *model.BulkResult) error {
+	conditions, args := filterConditions(op.Filter, nil)
+	countQuery := "SELECT count(*) FROM songs WHERE 1=1" + conditions
+	idsQuery := "SELECT id FROM songs WHERE 1=1" + conditions + " ORDER BY id"
+	if op.MaxReportedIDs > 0 {
+		idsQuery += fmt.Sprintf(" LIMIT %d", op.MaxReportedIDs)
+	}
+
+	txOptions := pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+	return pgx.BeginTxFunc(ctx, s.pool, txOptions, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, countQuery, args...).Scan(&result.Matched); err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, idsQuery, args...)
+		if err != nil {
+			return err
+		}
+		result.IDs, err = pgx.AppendRows(result.IDs, rows, pgx.RowTo[uint64])
+		return err
+	})
+}
+
+// reportedIDs returns the first limit ids, or all of them when limit is 0.
+func reportedIDs(ids []uint64, limit int) []uint64 {
+	if limit > 0 && len(ids) > limit {
+		return ids[:limit]
+	}
+	return ids
+}
+
+// songColumns selects a full model.Song from songs s joined with groups g,
+// in the order scanSong reads them.
+const songColumns = "s.id, s.song, g.name, s.group_id, s.release_date, s.link, s.text, s.created_at, s.updated_at"
+
+func scanSong(row pgx.Row, song *model.Song) error {
+	return row.Scan(
+		&song.ID,
+		&song.Song,
+		&song.Group,
+		&song.GroupID,
+		&song.ReleaseDate,
+		&song.Link,
+		&song.Text,
+		&song.CreatedAt,
+		&song.UpdatedAt,
+	)
+}
+
+// ensureGroup returns the id of the group with the given name, creating it
+// when songs are written with a group that does not exist yet.
+func ensureGroup(ctx context.Context, tx pgx.Tx, name string) (uint64, error) {
+	query := `
+INSERT INTO groups (name) VALUES ($1)
+ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+RETURNING id
+`
+
+	var id uint64
+	err := tx.QueryRow(ctx, query, name).Scan(&id)
+	return id, err
+}
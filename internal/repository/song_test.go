@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"reflect"
+	"song_lib/internal/domain/model"
+	"testing"
+)
+
+func TestMergeChanges(t *testing.T) {
+	cursor := func(xid, seq uint64) model.ChangeCursor {
+		return model.ChangeCursor{XID: xid, Seq: seq}
+	}
+	upsert := func(id, xid, seq uint64) songChange {
+		return songChange{cursor: cursor(xid, seq), song: model.Song{ID: id}}
+	}
+	deletion := func(id, xid, seq uint64) tombstoneChange {
+		return tombstoneChange{cursor: cursor(xid, seq), tombstone: model.SongTombstone{ID: id}}
+	}
+
+	since := cursor(5, 1)
+
+	tests := []struct {
+		name          string
+		limit         int
+		upserts       []songChange
+		deletions     []tombstoneChange
+		wantUpserts   []uint64
+		wantDeletions []uint64
+		wantLast      model.ChangeCursor
+		wantHasMore   bool
+	}{
+		{
+			name:     "nothing new keeps the cursor",
+			limit:    10,
+			wantLast: since,
+		},
+		{
+			name:        "upserts only",
+			limit:       10,
+			upserts:     []songChange{upsert(1, 6, 1), upsert(2, 6, 2)},
+			wantUpserts: []uint64{1, 2},
+			wantLast:    cursor(6, 2),
+		},
+		{
+			name:          "interleaved by xid then seq",
+			limit:         10,
+			upserts:       []songChange{upsert(1, 6, 9), upsert(2, 8, 1)},
+			deletions:     []tombstoneChange{deletion(3, 7, 2), deletion(4, 8, 3)},
+			wantUpserts:   []uint64{1, 2},
+			wantDeletions: []uint64{3, 4},
+			wantLast:      cursor(8, 3),
+		},
+		{
+			name:          "lower seq in a later transaction comes later",
+			limit:         1,
+			upserts:       []songChange{upsert(1, 9, 1)},
+			deletions:     []tombstoneChange{deletion(2, 7, 50)},
+			wantDeletions: []uint64{2},
+			wantLast:      cursor(7, 50),
+			wantHasMore:   true,
+		},
+		{
+			name:        "cut off at the limit",
+			limit:       2,
+			upserts:     []songChange{upsert(1, 6, 1), upsert(2, 6, 2), upsert(3, 6, 3)},
+			wantUpserts: []uint64{1, 2},
+			wantLast:    cursor(6, 2),
+			wantHasMore: true,
+		},
+		{
+			name:          "exactly the limit",
+			limit:         2,
+			upserts:       []songChange{upsert(1, 6, 1)},
+			deletions:     []tombstoneChange{deletion(2, 6, 2)},
+			wantUpserts:   []uint64{1},
+			wantDeletions: []uint64{2},
+			wantLast:      cursor(6, 2),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := mergeChanges(since, tt.limit, tt.upserts, tt.deletions)
+
+			if set.Upserts == nil || set.Deletions == nil {
+				t.Fatal("Upserts and Deletions must not be nil")
+			}
+
+			upserts := []uint64{}
+			for _, s := range set.Upserts {
+				upserts = append(upserts, s.ID)
+			}
+			deletions := []uint64{}
+			for _, d := range set.Deletions {
+				deletions = append(deletions, d.ID)
+			}
+
+			if tt.wantUpserts == nil {
+				tt.wantUpserts = []uint64{}
+			}
+			if tt.wantDeletions == nil {
+				tt.wantDeletions = []uint64{}
+			}
+			if !reflect.DeepEqual(upserts, tt.wantUpserts) {
+				t.Errorf("upserts = %v, want %v", upserts, tt.wantUpserts)
+			}
+			if !reflect.DeepEqual(deletions, tt.wantDeletions) {
+				t.Errorf("deletions = %v, want %v", deletions, tt.wantDeletions)
+			}
+			if set.Last != tt.wantLast {
+				t.Errorf("last = %+v, want %+v", set.Last, tt.wantLast)
+			}
+			if set.HasMore != tt.wantHasMore {
+				t.Errorf("hasMore = %v, want %v", set.HasMore, tt.wantHasMore)
+			}
+		})
+	}
+}
+
+func TestFilterConditions(t *testing.T) {
+	const (
+		groupIn  = " AND id IN (SELECT sa.song_id FROM song_artists sa JOIN groups g ON g.id = sa.group_id WHERE g.name = "
+		albumIn  = " AND id IN (SELECT t.song_id FROM album_tracks t JOIN albums a ON a.id = t.album_id WHERE a.title = "
+		personIn = " AND id IN (SELECT sp.song_id FROM song_people sp JOIN people p ON p.id = sp.person_id WHERE "
+		taggedIn = " AND id IN (SELECT st.song_id FROM song_tags st JOIN tags t ON t.id = st.tag_id WHERE t.name = ANY("
+	)
+
+	tests := []struct {
+		name     string
+		filter   model.LibraryFilter
+		args     []interface{}
+		want     string
+		wantArgs []interface{}
+	}{
+		{
+			name:   "empty",
+			filter: model.LibraryFilter{},
+			want:   "",
+		},
+		{
+			name:     "song",
+			filter:   model.LibraryFilter{Song: "Uprising"},
+			want:     " AND song = $1",
+			wantArgs: []interface{}{"Uprising"},
+		},
+		{
+			name:     "placeholders continue after existing args",
+			filter:   model.LibraryFilter{Group: "Muse", Song: "Uprising"},
+			args:     []interface{}{42},
+			want:     groupIn + "$2) AND song = $3",
+			wantArgs: []interface{}{42, "Muse", "Uprising"},
+		},
+		{
+			name:     "album",
+			filter:   model.LibraryFilter{Album: "The Resistance"},
+			want:     albumIn + "$1)",
+			wantArgs: []interface{}{"The Resistance"},
+		},
+		{
+			name:   "people in role order",
+			filter: model.LibraryFilter{Producer: "Rich Costey", Lyricist: "Matt Bellamy"},
+			want: personIn + "sp.role = $1 AND p.name = $2)" +
+				personIn + "sp.role = $3 AND p.name = $4)",
+			wantArgs: []interface{}{model.PersonLyricist, "Matt Bellamy", model.PersonProducer, "Rich Costey"},
+		},
+		{
+			name:     "all tags",
+			filter:   model.LibraryFilter{Tags: []string{"rock", "live"}},
+			want:     taggedIn + "$1) GROUP BY st.song_id HAVING count(*) = $2)",
+			wantArgs: []interface{}{[]string{"rock", "live"}, 2},
+		},
+		{
+			name:     "explicit all tags",
+			filter:   model.LibraryFilter{Tags: []string{"rock"}, TagsMatch: model.TagsMatchAll},
+			want:     taggedIn + "$1) GROUP BY st.song_id HAVING count(*) = $2)",
+			wantArgs: []interface{}{[]string{"rock"}, 1},
+		},
+		{
+			name:     "any tag",
+			filter:   model.LibraryFilter{Tags: []string{"rock", "live"}, TagsMatch: model.TagsMatchAny},
+			want:     taggedIn + "$1))",
+			wantArgs: []interface{}{[]string{"rock", "live"}},
+		},
+		{
+			name: "everything",
+			filter: model.LibraryFilter{
+				Group:     "Muse",
+				Song:      "Uprising",
+				Album:     "The Resistance",
+				Composer:  "Matt Bellamy",
+				Tags:      []string{"rock"},
+				TagsMatch: model.TagsMatchAny,
+			},
+			want: groupIn + "$1) AND song = $2" + albumIn + "$3)" +
+				personIn + "sp.role = $4 AND p.name = $5)" +
+				taggedIn + "$6))",
+			wantArgs: []interface{}{"Muse", "Uprising", "The Resistance", model.PersonComposer, "Matt Bellamy", []string{"rock"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args := filterConditions(tt.filter, tt.args)
+
+			if got != tt.want {
+				t.Errorf("conditions =\n%s\nwant\n%s", got, tt.want)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestReportedIDs(t *testing.T) {
+	ids := []uint64{1, 2, 3}
+
+	tests := []struct {
+		name  string
+		limit int
+		want  []uint64
+	}{
+		{name: "no limit", limit: 0, want: []uint64{1, 2, 3}},
+		{name: "under the limit", limit: 5, want: []uint64{1, 2, 3}},
+		{name: "at the limit", limit: 3, want: []uint64{1, 2, 3}},
+		{name: "over the limit", limit: 2, want: []uint64{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reportedIDs(ids, tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportedIDs(%v, %d) = %v, want %v", ids, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
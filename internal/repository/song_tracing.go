@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/repository"
+	"song_lib/internal/tracing"
+	"time"
+)
+
+// songTracing wraps every repository.Song method in a span.
+type songTracing struct {
+	next   repository.Song
+	tracer *tracing.Tracer
+}
+
+func newSongTracing(next repository.Song, tracer *tracing.Tracer) *songTracing {
+	return &songTracing{
+		next:   next,
+		tracer: tracer,
+	}
+}
+
+func (s *songTracing) GetSongs(ctx context.Context, filter model.LibraryFilter) ([]model.SongDetails, error) {
+	ctx, span := s.tracer.Start(ctx, "repository.Song/GetSongs", tracing.SpanKindInternal)
+	defer span.End()
+
+	songs, err := s.next.GetSongs(ctx, filter)
+	span.RecordError(err)
+	return songs, err
+}
+
+func (s *songTracing) GetVerses(ctx context.Context, filter model.VersesRequest) ([]string, time.Time, error) {
+	ctx, span := s.tracer.Start(ctx, "repository.Song/GetVerses", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("song.id", filter.SongID)
+
+	verses, updatedAt, err := s.next.GetVerses(ctx, filter)
+	span.RecordError(err)
+	return verses, updatedAt, err
+}
+
+func (s *songTracing) Get(ctx context.Context, id uint64) (model.Song, error) {
+	ctx, span := s.tracer.Start(ctx, "repository.Song/Get", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("song.id", id)
+
+	song, err := s.next.Get(ctx, id)
+	span.RecordError(err)
+	return song, err
+}
+
+func (s *songTracing) Add(ctx context.Context, song model.Song) (uint64, error) {
+	ctx, span := s.tracer.Start(ctx, "repository.Song/Add", tracing.SpanKindInternal)
+	defer span.End()
+
+	id, err := s.next.Add(ctx, song)
+	span.RecordError(err)
+	return id, err
+}
+
+func (s *songTracing) Delete(ctx context.Context, id uint64) error {
+	ctx, span := s.tracer.Start(ctx, "repository.Song/Delete", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("song.id", id)
+
+	err := s.next.Delete(ctx, id)
+	span.RecordError(err)
+	return err
+}
+
+func (s *songTracing) Update(ctx context.Context, song model.Song) (model.Song, error) {
+	ctx, span := s.tracer.Start(ctx, "repository.Song/Update", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("song.id", song.ID)
+
+	updated, err := s.next.Update(ctx, song)
+	span.RecordError(err)
+	return updated, err
+}
+
+func (s *songTracing) GetChanges(ctx context.Context, since model.ChangeCursor, limit int) (model.ChangeSet, error) {
+	ctx, span := s.tracer.Start(ctx, "repository.Song/GetChanges", tracing.SpanKindInternal)
+	defer span.End()
+
+	changes, err := s.next.GetChanges(ctx, since, limit)
+	span.RecordError(err)
+	return changes, err
+}
+
+func (s *songTracing) Bulk(ctx context.Context, op model.BulkOperation) (model.BulkResult, error) {
+	ctx, span := s.tracer.Start(ctx, "repository.Song/Bulk", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("bulk.operation", op.Operation)
+	span.SetAttribute("bulk.dry_run", op.DryRun)
+
+	result, err := s.next.Bulk(ctx, op)
+	span.SetAttribute("bulk.matched", result.Matched)
+	span.RecordError(err)
+	return result, err
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"song_lib/internal/domain/model"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type Tag struct {
+	pool *pgxpool.Pool
+	// replica serves the read methods. It is the primary pool when no
+	// replica is configured.
+	replica *pgxpool.Pool
+	log     *logrus.Logger
+}
+
+func NewTag(pool, replica *pgxpool.Pool, log *logrus.Logger) *Tag {
+	if replica == nil {
+		replica = pool
+	}
+
+	return &Tag{
+		pool:    pool,
+		replica: replica,
+		log:     log,
+	}
+}
+
+// List returns tags with their song counts, most used first. Tags no song
+// carries any more are listed with a count of zero.
+func (t *Tag) List(ctx context.Context, request model.TagsRequest) ([]model.TagCount, error) {
+	log := t.log.WithContext(ctx).WithField("op", "internal/repository/tag/List")
+
+	query := `
+SELECT t.name, count(st.song_id)
+FROM tags t
+LEFT JOIN song_tags st ON st.tag_id = t.id
+GROUP BY t.id, t.name
+ORDER BY count(st.song_id) DESC, t.name
+LIMIT $1 OFFSET $2
+`
+
+	log.Debugf("Executing query: %s with args: [%d, %d]", query, request.PerPage, request.Page*request.PerPage)
+
+	rows, err := t.replica.Query(ctx, query, request.PerPage, request.Page*request.PerPage)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []model.TagCount{}
+	for rows.Next() {
+		tag := model.TagCount{}
+		if err := rows.Scan(&tag.Name, &tag.Songs); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	log.Infof("Successfully retrieved %d tags", len(tags))
+	return tags, nil
+}
+
+func (t *Tag) GetSongTags(ctx context.Context, songID uint64) (model.SongTags, error) {
+	log := t.log.WithContext(ctx).WithField("op", "internal/repository/tag/GetSongTags")
+
+	tags, err := songTags(ctx, t.replica, songID)
+	if err != nil {
+		if !errors.Is(err, model.ErrNotFound) {
+			log.Error(err)
+		}
+		return model.SongTags{}, err
+	}
+
+	return tags, nil
+}
+
+func (t *Tag) Attach(ctx context.Context, request model.AttachTags) (model.SongTags, error) {
+	log := t.log.WithContext(ctx).WithField("op", "internal/repository/tag/Attach")
+
+	log.Debugf("Attaching tags %v to song ID: %d", request.Tags, request.SongID)
+
+	ensureTags := `
+INSERT INTO tags (name) SELECT unnest($1::text[])
+ON CONFLICT (name) DO NOTHING
+`
+	attach := `
+INSERT INTO song_tags (song_id, tag_id)
+SELECT $1, id FROM tags WHERE name = ANY($2)
+ON CONFLICT (song_id, tag_id) DO NOTHING
+`
+
+	var tags model.SongTags
+	err := pgx.BeginFunc(ctx, t.pool, func(tx pgx.Tx) error {
+		if err := lockSong(ctx, tx, request.SongID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, ensureTags, request.Tags); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, attach, request.SongID, request.Tags); err != nil {
+			return err
+		}
+
+		var err error
+		tags, err = songTags(ctx, tx, request.SongID)
+		return err
+	})
+	if err != nil {
+		if !errors.Is(err, model.ErrNotFound) {
+			log.Error(err)
+		}
+		return model.SongTags{}, err
+	}
+
+	log.Infof("Song ID %d now has %d tags", tags.SongID, len(tags.Tags))
+	return tags, nil
+}
+
+// Detach removes a tag from a song. Removing a tag the song does not carry
+// is not an error.
+func (t *Tag) Detach(ctx context.Context, request model.DetachTag) (model.SongTags, error) {
+	log := t.log.WithContext(ctx).WithField("op", "internal/repository/tag/Detach")
+
+	log.Debugf("Detaching tag %q from song ID: %d", request.Tag, request.SongID)
+
+	detach := "DELETE FROM song_tags WHERE song_id = $1 AND tag_id = (SELECT id FROM tags WHERE name = $2)"
+
+	var tags model.SongTags
+	err := pgx.BeginFunc(ctx, t.pool, func(tx pgx.Tx) error {
+		if err := lockSong(ctx, tx, request.SongID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, detach, request.SongID, request.Tag); err != nil {
+			return err
+		}
+
+		var err error
+		tags, err = songTags(ctx, tx, request.SongID)
+		return err
+	})
+	if err != nil {
+		if !errors.Is(err, model.ErrNotFound) {
+			log.Error(err)
+		}
+		return model.SongTags{}, err
+	}
+
+	log.Infof("Song ID %d now has %d tags", tags.SongID, len(tags.Tags))
+	return tags, nil
+}
+
+// lockSong keeps a song from being deleted until the transaction ends.
+func lockSong(ctx context.Context, tx pgx.Tx, songID uint64) error {
+	var id uint64
+	err := tx.QueryRow(ctx, "SELECT id FROM songs WHERE id = $1 FOR UPDATE", songID).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("song %d: %w", songID, model.ErrNotFound)
+	}
+
+	return err
+}
+
+// songTags reads the tags of a song in name order.
+func songTags(ctx context.Context, q querier, songID uint64) (model.SongTags, error) {
+	query := `
+SELECT s.id, coalesce(array_agg(t.name ORDER BY t.name) FILTER (WHERE t.name IS NOT NULL), '{}')
+FROM songs s
+LEFT JOIN song_tags st ON st.song_id = s.id
+LEFT JOIN tags t ON t.id = st.tag_id
+WHERE s.id = $1
+GROUP BY s.id
+`
+
+	var tags model.SongTags
+	err := q.QueryRow(ctx, query, songID).Scan(&tags.SongID, &tags.Tags)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.SongTags{}, fmt.Errorf("song %d: %w", songID, model.ErrNotFound)
+	}
+
+	return tags, err
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/repository"
+	"song_lib/internal/tracing"
+)
+
+// tagTracing wraps every repository.Tag method in a span.
+type tagTracing struct {
+	next   repository.Tag
+	tracer *tracing.Tracer
+}
+
+func newTagTracing(next repository.Tag, tracer *tracing.Tracer) *tagTracing {
+	return &tagTracing{
+		next:   next,
+		tracer: tracer,
+	}
+}
+
+func (t *tagTracing) List(ctx context.Context, request model.TagsRequest) ([]model.TagCount, error) {
+	ctx, span := t.tracer.Start(ctx, "repository.Tag/List", tracing.SpanKindInternal)
+	defer span.End()
+
+	tags, err := t.next.List(ctx, request)
+	span.RecordError(err)
+	return tags, err
+}
+
+func (t *tagTracing) GetSongTags(ctx context.Context, songID uint64) (model.SongTags, error) {
+	ctx, span := t.tracer.Start(ctx, "repository.Tag/GetSongTags", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("song.id", songID)
+
+	tags, err := t.next.GetSongTags(ctx, songID)
+	span.RecordError(err)
+	return tags, err
+}
+
+func (t *tagTracing) Attach(ctx context.Context, request model.AttachTags) (model.SongTags, error) {
+	ctx, span := t.tracer.Start(ctx, "repository.Tag/Attach", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("song.id", request.SongID)
+
+	tags, err := t.next.Attach(ctx, request)
+	span.RecordError(err)
+	return tags, err
+}
+
+func (t *tagTracing) Detach(ctx context.Context, request model.DetachTag) (model.SongTags, error) {
+	ctx, span := t.tracer.Start(ctx, "repository.Tag/Detach", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("song.id", request.SongID)
+
+	tags, err := t.next.Detach(ctx, request)
+	span.RecordError(err)
+	return tags, err
+}
@@ -0,0 +1,332 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type sink interface {
+	write(ctx context.Context, payload []byte) error
+	close() error
+}
+
+type fileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newFileSink(path string) (*fileSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open trace file: %w", err)
+	}
+
+	return &fileSink{file: file}, nil
+}
+
+// write appends one OTLP request per line, the layout the collector's file
+// exporter uses.
+func (s *fileSink) write(_ context.Context, payload []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.file.Write(append(payload, '\n'))
+	return err
+}
+
+func (s *fileSink) close() error {
+	return s.file.Close()
+}
+
+type httpSink struct {
+	endpoint string
+	client   *http.Client
+}
+
+func newHTTPSink(endpoint string, timeout time.Duration) *httpSink {
+	return &httpSink{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *httpSink) write(ctx context.Context, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("collector responded with %s", resp.Status)
+	}
+
+	return nil
+}
+
+func (s *httpSink) close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// Exporter batches finished spans and writes them as OTLP/JSON in the
+// background. Spans are dropped rather than blocking the request path when
+// the queue is full.
+type Exporter struct {
+	sink          sink
+	serviceName   string
+	batchSize     int
+	interval      time.Duration
+	exportTimeout time.Duration
+	log           *logrus.Logger
+
+	queue chan *Span
+	stop  chan struct{}
+	done  chan struct{}
+	once  sync.Once
+}
+
+func newExporter(s sink, serviceName string, batchSize int, interval, exportTimeout time.Duration, log *logrus.Logger) *Exporter {
+	if batchSize <= 0 {
+		batchSize = 512
+	}
+
+	e := &Exporter{
+		sink:          s,
+		serviceName:   serviceName,
+		batchSize:     batchSize,
+		interval:      interval,
+		exportTimeout: exportTimeout,
+		log:           log,
+		queue:         make(chan *Span, batchSize*4),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go e.run()
+
+	return e
+}
+
+// Enqueue queues a finished span. Spans ending after Shutdown are dropped.
+func (e *Exporter) Enqueue(span *Span) {
+	select {
+	case <-e.stop:
+		return
+	default:
+	}
+
+	select {
+	case e.queue <- span:
+	default:
+		e.log.WithField("op", "internal/tracing/exporter/Enqueue").Warn("Trace queue is full, dropping span")
+	}
+}
+
+func (e *Exporter) run() {
+	defer close(e.done)
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, e.batchSize)
+	for {
+		select {
+		case span := <-e.queue:
+			batch = append(batch, span)
+			if len(batch) >= e.batchSize {
+				batch = e.flush(batch)
+			}
+		case <-ticker.C:
+			batch = e.flush(batch)
+		case <-e.stop:
+			for {
+				select {
+				case span := <-e.queue:
+					batch = append(batch, span)
+				default:
+					e.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+func (e *Exporter) flush(batch []*Span) []*Span {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	log := e.log.WithField("op", "internal/tracing/exporter/flush")
+
+	payload, err := json.Marshal(e.encode(batch))
+	if err != nil {
+		log.Error(err)
+		return batch[:0]
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.exportTimeout)
+	defer cancel()
+
+	if err := e.sink.write(ctx, payload); err != nil {
+		log.WithError(err).Errorf("Failed to export %d spans", len(batch))
+	}
+
+	return batch[:0]
+}
+
+// Shutdown flushes pending spans and closes the sink. The sink is closed
+// even when ctx ends first, abandoning the final flush.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.once.Do(func() { close(e.stop) })
+
+	select {
+	case <-e.done:
+		return e.sink.close()
+	case <-ctx.Done():
+		return errors.Join(ctx.Err(), e.sink.close())
+	}
+}
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              SpanKind        `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+// Values follow the OTLP StatusCode enum.
+const (
+	statusOK    = 1
+	statusError = 2
+)
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+func (e *Exporter) encode(batch []*Span) otlpRequest {
+	spans := make([]otlpSpan, 0, len(batch))
+	for _, s := range batch {
+		s.mu.Lock()
+		span := otlpSpan{
+			TraceID:           s.sc.TraceID.String(),
+			SpanID:            s.sc.SpanID.String(),
+			Name:              s.name,
+			Kind:              s.kind,
+			StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+			Attributes:        encodeAttributes(s.attributes),
+			Status:            otlpStatus{Code: statusOK},
+		}
+		if s.err != nil {
+			span.Status = otlpStatus{Code: statusError, Message: s.err.Error()}
+		}
+		s.mu.Unlock()
+
+		if s.parent.IsValid() {
+			span.ParentSpanID = s.parent.String()
+		}
+
+		spans = append(spans, span)
+	}
+
+	return otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: encodeAttributes(map[string]any{"service.name": e.serviceName}),
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "song_lib/internal/tracing"},
+				Spans: spans,
+			}},
+		}},
+	}
+}
+
+func encodeAttributes(attrs map[string]any) []otlpAttribute {
+	out := make([]otlpAttribute, 0, len(attrs))
+	for key, v := range attrs {
+		var value otlpValue
+		switch v := v.(type) {
+		case string:
+			value.StringValue = &v
+		case int:
+			s := strconv.Itoa(v)
+			value.IntValue = &s
+		case int64:
+			s := strconv.FormatInt(v, 10)
+			value.IntValue = &s
+		case uint64:
+			s := strconv.FormatUint(v, 10)
+			value.IntValue = &s
+		case float64:
+			value.DoubleValue = &v
+		case bool:
+			value.BoolValue = &v
+		default:
+			s := fmt.Sprint(v)
+			value.StringValue = &s
+		}
+
+		out = append(out, otlpAttribute{Key: key, Value: value})
+	}
+
+	return out
+}
@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type querySpanKey struct{}
+
+// QueryTracer implements pgx.QueryTracer, recording a client span for every
+// SQL statement executed through the pool.
+type QueryTracer struct {
+	tracer *Tracer
+}
+
+func NewQueryTracer(tracer *Tracer) *QueryTracer {
+	return &QueryTracer{
+		tracer: tracer,
+	}
+}
+
+func (q *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, span := q.tracer.Start(ctx, "db "+statementVerb(data.SQL), SpanKindClient)
+	if span == nil {
+		return ctx
+	}
+
+	span.SetAttribute("db.system", "postgresql")
+	span.SetAttribute("db.statement", data.SQL)
+
+	return context.WithValue(ctx, querySpanKey{}, span)
+}
+
+func (q *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span, _ := ctx.Value(querySpanKey{}).(*Span)
+	if span == nil {
+		return
+	}
+
+	span.SetAttribute("db.rows_affected", data.CommandTag.RowsAffected())
+	span.RecordError(data.Err)
+	span.End()
+}
+
+func statementVerb(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "query"
+	}
+
+	return strings.ToUpper(fields[0])
+}
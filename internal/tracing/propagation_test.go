@@ -0,0 +1,84 @@
+package tracing
+
+import "testing"
+
+func TestParseTraceparent(t *testing.T) {
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+
+	tests := []struct {
+		name        string
+		header      string
+		wantOK      bool
+		wantSampled bool
+	}{
+		{name: "sampled", header: "00-" + traceID + "-" + spanID + "-01", wantOK: true, wantSampled: true},
+		{name: "not sampled", header: "00-" + traceID + "-" + spanID + "-00", wantOK: true},
+		{name: "other flags ignored", header: "00-" + traceID + "-" + spanID + "-03", wantOK: true, wantSampled: true},
+		{name: "surrounding spaces", header: " 00-" + traceID + "-" + spanID + "-01 ", wantOK: true, wantSampled: true},
+		{name: "future version", header: "01-" + traceID + "-" + spanID + "-01", wantOK: true, wantSampled: true},
+		{name: "future version with extra fields", header: "01-" + traceID + "-" + spanID + "-01-extra", wantOK: true, wantSampled: true},
+		{name: "empty", header: ""},
+		{name: "too few fields", header: "00-" + traceID + "-" + spanID},
+		{name: "version 00 with extra fields", header: "00-" + traceID + "-" + spanID + "-01-extra"},
+		{name: "forbidden version", header: "ff-" + traceID + "-" + spanID + "-01"},
+		{name: "non-hex version", header: "zz-" + traceID + "-" + spanID + "-01"},
+		{name: "long version", header: "000-" + traceID + "-" + spanID + "-01"},
+		{name: "short trace id", header: "00-" + traceID[1:] + "-" + spanID + "-01"},
+		{name: "short span id", header: "00-" + traceID + "-" + spanID[1:] + "-01"},
+		{name: "long flags", header: "00-" + traceID + "-" + spanID + "-001"},
+		{name: "non-hex trace id", header: "00-" + "x" + traceID[1:] + "-" + spanID + "-01"},
+		{name: "non-hex span id", header: "00-" + traceID + "-" + "x" + spanID[1:] + "-01"},
+		{name: "non-hex flags", header: "00-" + traceID + "-" + spanID + "-0x"},
+		{name: "zero trace id", header: "00-00000000000000000000000000000000-" + spanID + "-01"},
+		{name: "zero span id", header: "00-" + traceID + "-0000000000000000-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, ok := ParseTraceparent(tt.header)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseTraceparent(%q) ok = %v, want %v", tt.header, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+
+			if sc.TraceID.String() != traceID {
+				t.Errorf("trace id = %s, want %s", sc.TraceID, traceID)
+			}
+			if sc.SpanID.String() != spanID {
+				t.Errorf("span id = %s, want %s", sc.SpanID, spanID)
+			}
+			if sc.Sampled != tt.wantSampled {
+				t.Errorf("sampled = %v, want %v", sc.Sampled, tt.wantSampled)
+			}
+		})
+	}
+}
+
+func TestTraceparentRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		sampled bool
+	}{
+		{name: "sampled", sampled: true},
+		{name: "not sampled", sampled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := SpanContext{TraceID: newTraceID(), SpanID: newSpanID(), Sampled: tt.sampled}
+
+			got, ok := ParseTraceparent(sc.Traceparent())
+			if !ok {
+				t.Fatalf("ParseTraceparent(%q) failed", sc.Traceparent())
+			}
+			if got != sc {
+				t.Errorf("round trip = %+v, want %+v", got, sc)
+			}
+		})
+	}
+}
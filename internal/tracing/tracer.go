@@ -0,0 +1,98 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"song_lib/internal/config"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Tracer struct {
+	enabled     bool
+	sampleRatio float64
+	exporter    *Exporter
+}
+
+// NewTracer returns a tracer exporting to the configured sink. When tracing is
+// disabled it returns a tracer whose Start is a no-op.
+func NewTracer(cfg *config.Tracing, log *logrus.Logger) (*Tracer, error) {
+	if !cfg.Enabled {
+		return &Tracer{}, nil
+	}
+
+	var s sink
+	switch cfg.Exporter {
+	case "file":
+		fs, err := newFileSink(cfg.FilePath)
+		if err != nil {
+			return nil, err
+		}
+		s = fs
+	case "http":
+		if cfg.Endpoint == "" {
+			return nil, fmt.Errorf("tracing endpoint is required for the http exporter")
+		}
+		s = newHTTPSink(cfg.Endpoint, cfg.ExportTimeout)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+	}
+
+	return &Tracer{
+		enabled:     true,
+		sampleRatio: cfg.SampleRatio,
+		exporter:    newExporter(s, cfg.ServiceName, cfg.BatchSize, cfg.FlushInterval, cfg.ExportTimeout, log),
+	}, nil
+}
+
+type remoteKey struct{}
+
+// ContextWithRemoteParent makes sc the parent of the next span started from
+// ctx. It is used for span contexts received over the wire.
+func ContextWithRemoteParent(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+func (t *Tracer) Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	if t == nil || !t.enabled {
+		return ctx, nil
+	}
+
+	var parent SpanContext
+	if span := SpanFromContext(ctx); span != nil {
+		parent = span.sc
+	} else if remote, ok := ctx.Value(remoteKey{}).(SpanContext); ok {
+		parent = remote
+	}
+
+	sc := SpanContext{SpanID: newSpanID()}
+	if parent.IsValid() {
+		sc.TraceID = parent.TraceID
+		sc.Sampled = parent.Sampled
+	} else {
+		sc.TraceID = newTraceID()
+		sc.Sampled = rand.Float64() < t.sampleRatio
+	}
+
+	span := &Span{
+		tracer: t,
+		sc:     sc,
+		parent: parent.SpanID,
+		name:   name,
+		kind:   kind,
+		start:  time.Now(),
+	}
+
+	return ContextWithSpan(ctx, span), span
+}
+
+// Shutdown flushes queued spans and releases the exporter.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	if t == nil || !t.enabled {
+		return nil
+	}
+
+	return t.exporter.Shutdown(ctx)
+}
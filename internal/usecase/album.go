@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Album struct {
+	albumRepo repository.Album
+	log       *logrus.Logger
+}
+
+func NewAlbum(albumRepo repository.Album, log *logrus.Logger) *Album {
+	return &Album{
+		albumRepo: albumRepo,
+		log:       log,
+	}
+}
+
+func (a *Album) List(ctx context.Context, request model.AlbumsRequest) ([]model.Album, error) {
+	log := a.log.WithContext(ctx).WithField("op", "internal/usecase/album/List")
+
+	if err := validateAlbumsRequest(request); err != nil {
+		log.Warn(err)
+		return nil, err
+	}
+
+	if request.PerPage == 0 {
+		request.PerPage = defaultPerPage
+		log.Infof("PerPage was set to default value: %d", request.PerPage)
+	}
+
+	albums, err := a.albumRepo.List(ctx, request)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	log.Infof("Successfully retrieved %d albums", len(albums))
+	return albums, nil
+}
+
+func (a *Album) Get(ctx context.Context, id uint64) (model.Album, error) {
+	log := a.log.WithContext(ctx).WithField("op", "internal/usecase/album/Get")
+
+	album, err := a.albumRepo.Get(ctx, id)
+	if err != nil {
+		log.Warn(err)
+		return model.Album{}, err
+	}
+
+	return album, nil
+}
+
+func (a *Album) Add(ctx context.Context, request model.AddAlbum) (model.Album, error) {
+	log := a.log.WithContext(ctx).WithField("op", "internal/usecase/album/Add")
+
+	if err := validateAddAlbum(request); err != nil {
+		log.Warn(err)
+		return model.Album{}, err
+	}
+
+	album, err := a.albumRepo.Add(ctx, request)
+	if err != nil {
+		log.Warn(err)
+		return model.Album{}, err
+	}
+
+	log.Infof("Successfully added album with ID: %d", album.ID)
+	return album, nil
+}
+
+func (a *Album) Update(ctx context.Context, request model.UpdateAlbum) (model.Album, error) {
+	log := a.log.WithContext(ctx).WithField("op", "internal/usecase/album/Update")
+
+	if err := validateUpdateAlbum(request); err != nil {
+		log.Warn(err)
+		return model.Album{}, err
+	}
+
+	album, err := a.albumRepo.Update(ctx, request)
+	if err != nil {
+		log.Warn(err)
+		return model.Album{}, err
+	}
+
+	log.Infof("Successfully updated album with ID: %d", album.ID)
+	return album, nil
+}
+
+func (a *Album) SetTracks(ctx context.Context, request model.SetAlbumTracks) (model.Album, error) {
+	log := a.log.WithContext(ctx).WithField("op", "internal/usecase/album/SetTracks")
+
+	if err := validateSetAlbumTracks(request); err != nil {
+		log.Warn(err)
+		return model.Album{}, err
+	}
+
+	album, err := a.albumRepo.SetTracks(ctx, request)
+	if err != nil {
+		log.Warn(err)
+		return model.Album{}, err
+	}
+
+	log.Infof("Successfully set %d tracks on album ID: %d", len(album.Tracks), album.ID)
+	return album, nil
+}
+
+func (a *Album) Delete(ctx context.Context, id uint64) error {
+	log := a.log.WithContext(ctx).WithField("op", "internal/usecase/album/Delete")
+
+	if err := a.albumRepo.Delete(ctx, id); err != nil {
+		log.Warn(err)
+		return err
+	}
+
+	log.Infof("Successfully deleted album with ID: %d", id)
+	return nil
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/usecase"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// albumMetrics counts errors returned by each usecase.Album method.
+type albumMetrics struct {
+	next   usecase.Album
+	errors *prometheus.CounterVec
+}
+
+func newAlbumMetrics(next usecase.Album, errors *prometheus.CounterVec) *albumMetrics {
+	return &albumMetrics{
+		next:   next,
+		errors: errors,
+	}
+}
+
+func (a *albumMetrics) observe(method string, err error) {
+	if err != nil {
+		a.errors.WithLabelValues("album", method, errorKind(err)).Inc()
+	}
+}
+
+func (a *albumMetrics) List(ctx context.Context, request model.AlbumsRequest) ([]model.Album, error) {
+	albums, err := a.next.List(ctx, request)
+	a.observe("List", err)
+	return albums, err
+}
+
+func (a *albumMetrics) Get(ctx context.Context, id uint64) (model.Album, error) {
+	album, err := a.next.Get(ctx, id)
+	a.observe("Get", err)
+	return album, err
+}
+
+func (a *albumMetrics) Add(ctx context.Context, request model.AddAlbum) (model.Album, error) {
+	added, err := a.next.Add(ctx, request)
+	a.observe("Add", err)
+	return added, err
+}
+
+func (a *albumMetrics) Update(ctx context.Context, request model.UpdateAlbum) (model.Album, error) {
+	updated, err := a.next.Update(ctx, request)
+	a.observe("Update", err)
+	return updated, err
+}
+
+func (a *albumMetrics) SetTracks(ctx context.Context, request model.SetAlbumTracks) (model.Album, error) {
+	updated, err := a.next.SetTracks(ctx, request)
+	a.observe("SetTracks", err)
+	return updated, err
+}
+
+func (a *albumMetrics) Delete(ctx context.Context, id uint64) error {
+	err := a.next.Delete(ctx, id)
+	a.observe("Delete", err)
+	return err
+}
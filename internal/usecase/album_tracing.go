@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/usecase"
+	"song_lib/internal/tracing"
+)
+
+// albumTracing wraps every usecase.Album method in a span.
+type albumTracing struct {
+	next   usecase.Album
+	tracer *tracing.Tracer
+}
+
+func newAlbumTracing(next usecase.Album, tracer *tracing.Tracer) *albumTracing {
+	return &albumTracing{
+		next:   next,
+		tracer: tracer,
+	}
+}
+
+func (a *albumTracing) List(ctx context.Context, request model.AlbumsRequest) ([]model.Album, error) {
+	ctx, span := a.tracer.Start(ctx, "usecase.Album/List", tracing.SpanKindInternal)
+	defer span.End()
+
+	albums, err := a.next.List(ctx, request)
+	span.RecordError(err)
+	return albums, err
+}
+
+func (a *albumTracing) Get(ctx context.Context, id uint64) (model.Album, error) {
+	ctx, span := a.tracer.Start(ctx, "usecase.Album/Get", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("album.id", id)
+
+	album, err := a.next.Get(ctx, id)
+	span.RecordError(err)
+	return album, err
+}
+
+func (a *albumTracing) Add(ctx context.Context, request model.AddAlbum) (model.Album, error) {
+	ctx, span := a.tracer.Start(ctx, "usecase.Album/Add", tracing.SpanKindInternal)
+	defer span.End()
+
+	added, err := a.next.Add(ctx, request)
+	span.RecordError(err)
+	return added, err
+}
+
+func (a *albumTracing) Update(ctx context.Context, request model.UpdateAlbum) (model.Album, error) {
+	ctx, span := a.tracer.Start(ctx, "usecase.Album/Update", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("album.id", request.ID)
+
+	updated, err := a.next.Update(ctx, request)
+	span.RecordError(err)
+	return updated, err
+}
+
+func (a *albumTracing) SetTracks(ctx context.Context, request model.SetAlbumTracks) (model.Album, error) {
+	ctx, span := a.tracer.Start(ctx, "usecase.Album/SetTracks", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("album.id", request.AlbumID)
+
+	updated, err := a.next.SetTracks(ctx, request)
+	span.RecordError(err)
+	return updated, err
+}
+
+func (a *albumTracing) Delete(ctx context.Context, id uint64) error {
+	ctx, span := a.tracer.Start(ctx, "usecase.Album/Delete", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("album.id", id)
+
+	err := a.next.Delete(ctx, id)
+	span.RecordError(err)
+	return err
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Group struct {
+	groupRepo repository.Group
+	log       *logrus.Logger
+}
+
+func NewGroup(groupRepo repository.Group, log *logrus.Logger) *Group {
+	return &Group{
+		groupRepo: groupRepo,
+		log:       log,
+	}
+}
+
+func (g *Group) List(ctx context.Context, request model.GroupsRequest) ([]model.Group, error) {
+	log := g.log.WithContext(ctx).WithField("op", "internal/usecase/group/List")
+
+	if err := validateGroupsRequest(request); err != nil {
+		log.Warn(err)
+		return nil, err
+	}
+
+	if request.PerPage == 0 {
+		request.PerPage = defaultPerPage
+		log.Infof("PerPage was set to default value: %d", request.PerPage)
+	}
+
+	groups, err := g.groupRepo.List(ctx, request)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	log.Infof("Successfully retrieved %d groups", len(groups))
+	return groups, nil
+}
+
+func (g *Group) Get(ctx context.Context, id uint64) (model.Group, error) {
+	log := g.log.WithContext(ctx).WithField("op", "internal/usecase/group/Get")
+
+	group, err := g.groupRepo.Get(ctx, id)
+	if err != nil {
+		log.Warn(err)
+		return model.Group{}, err
+	}
+
+	return group, nil
+}
+
+func (g *Group) Add(ctx context.Context, request model.AddGroup) (model.Group, error) {
+	log := g.log.WithContext(ctx).WithField("op", "internal/usecase/group/Add")
+
+	if err := validateAddGroup(request); err != nil {
+		log.Warn(err)
+		return model.Group{}, err
+	}
+
+	group, err := g.groupRepo.Add(ctx, request)
+	if err != nil {
+		log.Warn(err)
+		return model.Group{}, err
+	}
+
+	log.Infof("Successfully added group with ID: %d", group.ID)
+	return group, nil
+}
+
+func (g *Group) Update(ctx context.Context, request model.UpdateGroup) (model.Group, error) {
+	log := g.log.WithContext(ctx).WithField("op", "internal/usecase/group/Update")
+
+	if err := validateUpdateGroup(request); err != nil {
+		log.Warn(err)
+		return model.Group{}, err
+	}
+
+	group, err := g.groupRepo.Update(ctx, request)
+	if err != nil {
+		log.Warn(err)
+		return model.Group{}, err
+	}
+
+	log.Infof("Successfully updated group with ID: %d", group.ID)
+	return group, nil
+}
+
+func (g *Group) Delete(ctx context.Context, id uint64) error {
+	log := g.log.WithContext(ctx).WithField("op", "internal/usecase/group/Delete")
+
+	if err := g.groupRepo.Delete(ctx, id); err != nil {
+		log.Warn(err)
+		return err
+	}
+
+	log.Infof("Successfully deleted group with ID: %d", id)
+	return nil
+}
+
+func (g *Group) GetSongs(ctx context.Context, request model.GroupSongsRequest) ([]model.Song, error) {
+	log := g.log.WithContext(ctx).WithField("op", "internal/usecase/group/GetSongs")
+
+	if err := validateGroupSongsRequest(request); err != nil {
+		log.Warn(err)
+		return nil, err
+	}
+
+	if request.PerPage == 0 {
+		request.PerPage = defaultPerPage
+		log.Infof("PerPage was set to default value: %d", request.PerPage)
+	}
+
+	songs, err := g.groupRepo.GetSongs(ctx, request)
+	if err != nil {
+		log.Warn(err)
+		return nil, err
+	}
+
+	log.Infof("Successfully retrieved %d songs for group ID: %d", len(songs), request.GroupID)
+	return songs, nil
+}
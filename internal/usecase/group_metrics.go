@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/usecase"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// groupMetrics counts errors returned by each usecase.Group method.
+type groupMetrics struct {
+	next   usecase.Group
+	errors *prometheus.CounterVec
+}
+
+func newGroupMetrics(next usecase.Group, errors *prometheus.CounterVec) *groupMetrics {
+	return &groupMetrics{
+		next:   next,
+		errors: errors,
+	}
+}
+
+func (g *groupMetrics) observe(method string, err error) {
+	if err != nil {
+		g.errors.WithLabelValues("group", method, errorKind(err)).Inc()
+	}
+}
+
+func (g *groupMetrics) List(ctx context.Context, request model.GroupsRequest) ([]model.Group, error) {
+	groups, err := g.next.List(ctx, request)
+	g.observe("List", err)
+	return groups, err
+}
+
+func (g *groupMetrics) Get(ctx context.Context, id uint64) (model.Group, error) {
+	group, err := g.next.Get(ctx, id)
+	g.observe("Get", err)
+	return group, err
+}
+
+func (g *groupMetrics) Add(ctx context.Context, request model.AddGroup) (model.Group, error) {
+	added, err := g.next.Add(ctx, request)
+	g.observe("Add", err)
+	return added, err
+}
+
+func (g *groupMetrics) Update(ctx context.Context, request model.UpdateGroup) (model.Group, error) {
+	updated, err := g.next.Update(ctx, request)
+	g.observe("Update", err)
+	return updated, err
+}
+
+func (g *groupMetrics) Delete(ctx context.Context, id uint64) error {
+	err := g.next.Delete(ctx, id)
+	g.observe("Delete", err)
+	return err
+}
+
+func (g *groupMetrics) GetSongs(ctx context.Context, request model.GroupSongsRequest) ([]model.Song, error) {
+	songs, err := g.next.GetSongs(ctx, request)
+	g.observe("GetSongs", err)
+	return songs, err
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/usecase"
+	"song_lib/internal/tracing"
+)
+
+// groupTracing wraps every usecase.Group method in a span.
+type groupTracing struct {
+	next   usecase.Group
+	tracer *tracing.Tracer
+}
+
+func newGroupTracing(next usecase.Group, tracer *tracing.Tracer) *groupTracing {
+	return &groupTracing{
+		next:   next,
+		tracer: tracer,
+	}
+}
+
+func (g *groupTracing) List(ctx context.Context, request model.GroupsRequest) ([]model.Group, error) {
+	ctx, span := g.tracer.Start(ctx, "usecase.Group/List", tracing.SpanKindInternal)
+	defer span.End()
+
+	groups, err := g.next.List(ctx, request)
+	span.RecordError(err)
+	return groups, err
+}
+
+func (g *groupTracing) Get(ctx context.Context, id uint64) (model.Group, error) {
+	ctx, span := g.tracer.Start(ctx, "usecase.Group/Get", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("group.id", id)
+
+	group, err := g.next.Get(ctx, id)
+	span.RecordError(err)
+	return group, err
+}
+
+func (g *groupTracing) Add(ctx context.Context, request model.AddGroup) (model.Group, error) {
+	ctx, span := g.tracer.Start(ctx, "usecase.Group/Add", tracing.SpanKindInternal)
+	defer span.End()
+
+	added, err := g.next.Add(ctx, request)
+	span.RecordError(err)
+	return added, err
+}
+
+func (g *groupTracing) Update(ctx context.Context, request model.UpdateGroup) (model.Group, error) {
+	ctx, span := g.tracer.Start(ctx, "usecase.Group/Update", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("group.id", request.ID)
+
+	updated, err := g.next.Update(ctx, request)
+	span.RecordError(err)
+	return updated, err
+}
+
+func (g *groupTracing) Delete(ctx context.Context, id uint64) error {
+	ctx, span := g.tracer.Start(ctx, "usecase.Group/Delete", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("group.id", id)
+
+	err := g.next.Delete(ctx, id)
+	span.RecordError(err)
+	return err
+}
+
+func (g *groupTracing) GetSongs(ctx context.Context, request model.GroupSongsRequest) ([]model.Song, error) {
+	ctx, span := g.tracer.Start(ctx, "usecase.Group/GetSongs", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("group.id", request.GroupID)
+
+	songs, err := g.next.GetSongs(ctx, request)
+	span.RecordError(err)
+	return songs, err
+}
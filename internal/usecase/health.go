@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/repository"
+	"song_lib/migration"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const checkTimeout = 2 * time.Second
+
+type Health struct {
+	healthRepo repository.Health
+	log        *logrus.Logger
+	draining   atomic.Bool
+}
+
+func NewHealth(healthRepo repository.Health, log *logrus.Logger) *Health {
+	return &Health{
+		healthRepo: healthRepo,
+		log:        log,
+	}
+}
+
+func (h *Health) Readiness(ctx context.Context) model.Readiness {
+	log := h.log.WithContext(ctx).WithField("op", "internal/usecase/health/Readiness")
+
+	deps := []model.DependencyStatus{
+		h.check(ctx, "postgres", h.healthRepo.Ping),
+		h.check(ctx, "migrations", h.checkMigrations),
+	}
+
+	status := model.StatusOK
+	for _, d := range deps {
+		if d.Status != model.StatusOK {
+			log.Warnf("Dependency %s is not ready: %s", d.Name, d.Error)
+			status = model.StatusFail
+		}
+	}
+
+	if h.draining.Load() {
+		status = model.StatusShuttingDown
+	}
+
+	return model.Readiness{
+		Status:       status,
+		Dependencies: deps,
+	}
+}
+
+// Drain makes readiness fail from now on so load balancers stop routing new
+// traffic before the server shuts down.
+func (h *Health) Drain() {
+	h.draining.Store(true)
+}
+
+func (h *Health) check(ctx context.Context, name string, fn func(ctx context.Context) error) model.DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	if err := fn(ctx); err != nil {
+		return model.DependencyStatus{Name: name, Status: model.StatusFail, Error: err.Error()}
+	}
+
+	return model.DependencyStatus{Name: name, Status: model.StatusOK}
+}
+
+func (h *Health) checkMigrations(ctx context.Context) error {
+	expected, err := migration.LatestVersion()
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := h.healthRepo.MigrationVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		return fmt.Errorf("migration %d is dirty", version)
+	}
+	if version != expected {
+		return fmt.Errorf("schema version %d, expected %d", version, expected)
+	}
+
+	return nil
+}
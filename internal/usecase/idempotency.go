@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/repository"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Idempotency struct {
+	idempotencyRepo repository.Idempotency
+	ttl             time.Duration
+	log             *logrus.Logger
+}
+
+func NewIdempotency(idempotencyRepo repository.Idempotency, ttl time.Duration, log *logrus.Logger) *Idempotency {
+	return &Idempotency{
+		idempotencyRepo: idempotencyRepo,
+		ttl:             ttl,
+		log:             log,
+	}
+}
+
+func (i *Idempotency) Begin(ctx context.Context, scope, key, requestHash string) (model.IdempotencyRecord, bool, error) {
+	log := i.log.WithContext(ctx).WithField("op", "internal/usecase/idempotency/Begin")
+
+	record := model.IdempotencyRecord{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+	}
+
+	existing, created, err := i.idempotencyRepo.Reserve(ctx, record, i.ttl)
+	if err != nil {
+		log.Error(err)
+		return model.IdempotencyRecord{}, false, err
+	}
+
+	if !created {
+		log.Infof("Idempotency key %s already seen", key)
+	}
+
+	return existing, created, nil
+}
+
+func (i *Idempotency) Complete(ctx context.Context, record model.IdempotencyRecord) error {
+	log := i.log.WithContext(ctx).WithField("op", "internal/usecase/idempotency/Complete")
+
+	if err := i.idempotencyRepo.Complete(ctx, record); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (i *Idempotency) Release(ctx context.Context, scope, key string) error {
+	log := i.log.WithContext(ctx).WithField("op", "internal/usecase/idempotency/Release")
+
+	if err := i.idempotencyRepo.Release(ctx, scope, key); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// RunCleanup periodically deletes expired keys until the returned stop
+// function is called.
+func (i *Idempotency) RunCleanup(interval time.Duration) func(ctx context.Context) error {
+	log := i.log.WithField("op", "internal/usecase/idempotency/RunCleanup")
+
+	quit := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				n, err := i.idempotencyRepo.DeleteExpired(ctx)
+				cancel()
+				if err != nil {
+					log.Error(err)
+					continue
+				}
+				if n > 0 {
+					log.Infof("Deleted %d expired idempotency keys", n)
+				}
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func(ctx context.Context) error {
+		once.Do(func() { close(quit) })
+
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"song_lib/internal/domain/model"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Maintenance holds the read-only switch. It lives in memory, so every
+// instance has to be switched on its own.
+type Maintenance struct {
+	log *logrus.Logger
+
+	mu    sync.RWMutex
+	since *time.Time
+}
+
+func NewMaintenance(readOnly bool, log *logrus.Logger) *Maintenance {
+	m := &Maintenance{
+		log: log,
+	}
+	if readOnly {
+		now := time.Now().UTC()
+		m.since = &now
+	}
+
+	return m
+}
+
+func (m *Maintenance) ReadOnly() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.since != nil
+}
+
+func (m *Maintenance) Status() model.MaintenanceStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.status()
+}
+
+func (m *Maintenance) SetReadOnly(readOnly bool) model.MaintenanceStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.set(readOnly)
+	return m.status()
+}
+
+func (m *Maintenance) Toggle() model.MaintenanceStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.set(m.since == nil)
+	return m.status()
+}
+
+func (m *Maintenance) set(readOnly bool) {
+	log := m.log.WithField("op", "internal/usecase/maintenance/set")
+
+	switch {
+	case readOnly && m.since == nil:
+		now := time.Now().UTC()
+		m.since = &now
+		log.Warn("Read-only maintenance mode enabled")
+	case !readOnly && m.since != nil:
+		m.since = nil
+		log.Warn("Read-only maintenance mode disabled")
+	}
+}
+
+func (m *Maintenance) status() model.MaintenanceStatus {
+	return model.MaintenanceStatus{
+		ReadOnly: m.since != nil,
+		Since:    m.since,
+	}
+}
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"errors"
+	"song_lib/internal/domain/model"
+)
+
+// errorKind labels usecase error metrics so client mistakes can be told
+// apart from server faults.
+func errorKind(err error) string {
+	var validationErr *model.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return "validation"
+	case errors.Is(err, model.ErrNotFound):
+		return "not_found"
+	case errors.Is(err, model.ErrConflict):
+		return "conflict"
+	default:
+		return "internal"
+	}
+}
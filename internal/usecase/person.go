@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Person struct {
+	personRepo repository.Person
+	log        *logrus.Logger
+}
+
+func NewPerson(personRepo repository.Person, log *logrus.Logger) *Person {
+	return &Person{
+		personRepo: personRepo,
+		log:        log,
+	}
+}
+
+func (p *Person) List(ctx context.Context, request model.PeopleRequest) ([]model.Person, error) {
+	log := p.log.WithContext(ctx).WithField("op", "internal/usecase/person/List")
+
+	if err := validatePeopleRequest(request); err != nil {
+		log.Warn(err)
+		return nil, err
+	}
+
+	if request.PerPage == 0 {
+		request.PerPage = defaultPerPage
+		log.Infof("PerPage was set to default value: %d", request.PerPage)
+	}
+
+	people, err := p.personRepo.List(ctx, request)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	log.Infof("Successfully retrieved %d people", len(people))
+	return people, nil
+}
+
+func (p *Person) Get(ctx context.Context, id uint64) (model.Person, error) {
+	log := p.log.WithContext(ctx).WithField("op", "internal/usecase/person/Get")
+
+	person, err := p.personRepo.Get(ctx, id)
+	if err != nil {
+		log.Warn(err)
+		return model.Person{}, err
+	}
+
+	return person, nil
+}
+
+func (p *Person) Add(ctx context.Context, request model.AddPerson) (model.Person, error) {
+	log := p.log.WithContext(ctx).WithField("op", "internal/usecase/person/Add")
+
+	if err := validateAddPerson(request); err != nil {
+		log.Warn(err)
+		return model.Person{}, err
+	}
+
+	person, err := p.personRepo.Add(ctx, request)
+	if err != nil {
+		log.Warn(err)
+		return model.Person{}, err
+	}
+
+	log.Infof("Successfully added person with ID: %d", person.ID)
+	return person, nil
+}
+
+func (p *Person) Update(ctx context.Context, request model.UpdatePerson) (model.Person, error) {
+	log := p.log.WithContext(ctx).WithField("op", "internal/usecase/person/Update")
+
+	if err := validateUpdatePerson(request); err != nil {
+		log.Warn(err)
+		return model.Person{}, err
+	}
+
+	person, err := p.personRepo.Update(ctx, request)
+	if err != nil {
+		log.Warn(err)
+		return model.Person{}, err
+	}
+
+	log.Infof("Successfully updated person with ID: %d", person.ID)
+	return person, nil
+}
+
+func (p *Person) Delete(ctx context.Context, id uint64) error {
+	log := p.log.WithContext(ctx).WithField("op", "internal/usecase/person/Delete")
+
+	if err := p.personRepo.Delete(ctx, id); err != nil {
+		log.Warn(err)
+		return err
+	}
+
+	log.Infof("Successfully deleted person with ID: %d", id)
+	return nil
+}
+
+func (p *Person) GetSongs(ctx context.Context, request model.PersonSongsRequest) ([]model.Song, error) {
+	log := p.log.WithContext(ctx).WithField("op", "internal/usecase/person/GetSongs")
+
+	if err := validatePersonSongsRequest(request); err != nil {
+		log.Warn(err)
+		return nil, err
+	}
+
+	if request.PerPage == 0 {
+		request.PerPage = defaultPerPage
+		log.Infof("PerPage was set to default value: %d", request.PerPage)
+	}
+
+	songs, err := p.personRepo.GetSongs(ctx, request)
+	if err != nil {
+		log.Warn(err)
+		return nil, err
+	}
+
+	log.Infof("Successfully retrieved %d songs for person ID: %d", len(songs), request.PersonID)
+	return songs, nil
+}
+
+func (p *Person) GetCredits(ctx context.Context, songID uint64) ([]model.PersonCredit, error) {
+	log := p.log.WithContext(ctx).WithField("op", "internal/usecase/person/GetCredits")
+
+	credits, err := p.personRepo.GetCredits(ctx, songID)
+	if err != nil {
+		log.Warn(err)
+		return nil, err
+	}
+
+	return credits, nil
+}
+
+func (p *Person) SetCredits(ctx context.Context, request model.SetSongCredits) ([]model.PersonCredit, error) {
+	log := p.log.WithContext(ctx).WithField("op", "internal/usecase/person/SetCredits")
+
+	if err := validateSetSongCredits(request); err != nil {
+		log.Warn(err)
+		return nil, err
+	}
+
+	credits, err := p.personRepo.SetCredits(ctx, request)
+	if err != nil {
+		log.Warn(err)
+		return nil, err
+	}
+
+	log.Infof("Successfully set %d credits on song ID: %d", len(credits), request.SongID)
+	return credits, nil
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/usecase"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// personMetrics counts errors returned by each usecase.Person method.
+type personMetrics struct {
+	next   usecase.Person
+	errors *prometheus.CounterVec
+}
+
+func newPersonMetrics(next usecase.Person, errors *prometheus.CounterVec) *personMetrics {
+	return &personMetrics{
+		next:   next,
+		errors: errors,
+	}
+}
+
+func (p *personMetrics) observe(method string, err error) {
+	if err != nil {
+		p.errors.WithLabelValues("person", method, errorKind(err)).Inc()
+	}
+}
+
+func (p *personMetrics) List(ctx context.Context, request model.PeopleRequest) ([]model.Person, error) {
+	people, err := p.next.List(ctx, request)
+	p.observe("List", err)
+	return people, err
+}
+
+func (p *personMetrics) Get(ctx context.Context, id uint64) (model.Person, error) {
+	person, err := p.next.Get(ctx, id)
+	p.observe("Get", err)
+	return person, err
+}
+
+func (p *personMetrics) Add(ctx context.Context, request model.AddPerson) (model.Person, error) {
+	added, err := p.next.Add(ctx, request)
+	p.observe("Add", err)
+	return added, err
+}
+
+func (p *personMetrics) Update(ctx context.Context, request model.UpdatePerson) (model.Person, error) {
+	updated, err := p.next.Update(ctx, request)
+	p.observe("Update", err)
+	return updated, err
+}
+
+func (p *personMetrics) Delete(ctx context.Context, id uint64) error {
+	err := p.next.Delete(ctx, id)
+	p.observe("Delete", err)
+	return err
+}
+
+func (p *personMetrics) GetSongs(ctx context.Context, request model.PersonSongsRequest) ([]model.Song, error) {
+	songs, err := p.next.GetSongs(ctx, request)
+	p.observe("GetSongs", err)
+	return songs, err
+}
+
+func (p *personMetrics) GetCredits(ctx context.Context, songID uint64) ([]model.PersonCredit, error) {
+	credits, err := p.next.GetCredits(ctx, songID)
+	p.observe("GetCredits", err)
+	return credits, err
+}
+
+func (p *personMetrics) SetCredits(ctx context.Context, request model.SetSongCredits) ([]model.PersonCredit, error) {
+	credits, err := p.next.SetCredits(ctx, request)
+	p.observe("SetCredits", err)
+	return credits, err
+}
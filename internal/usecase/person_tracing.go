@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/usecase"
+	"song_lib/internal/tracing"
+)
+
+// personTracing wraps every usecase.Person method in a span.
+type personTracing struct {
+	next   usecase.Person
+	tracer *tracing.Tracer
+}
+
+func newPersonTracing(next usecase.Person, tracer *tracing.Tracer) *personTracing {
+	return &personTracing{
+		next:   next,
+		tracer: tracer,
+	}
+}
+
+func (p *personTracing) List(ctx context.Context, request model.PeopleRequest) ([]model.Person, error) {
+	ctx, span := p.tracer.Start(ctx, "usecase.Person/List", tracing.SpanKindInternal)
+	defer span.End()
+
+	people, err := p.next.List(ctx, request)
+	span.RecordError(err)
+	return people, err
+}
+
+func (p *personTracing) Get(ctx context.Context, id uint64) (model.Person, error) {
+	ctx, span := p.tracer.Start(ctx, "usecase.Person/Get", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("person.id", id)
+
+	person, err := p.next.Get(ctx, id)
+	span.RecordError(err)
+	return person, err
+}
+
+func (p *personTracing) Add(ctx context.Context, request model.AddPerson) (model.Person, error) {
+	ctx, span := p.tracer.Start(ctx, "usecase.Person/Add", tracing.SpanKindInternal)
+	defer span.End()
+
+	added, err := p.next.Add(ctx, request)
+	span.RecordError(err)
+	return added, err
+}
+
+func (p *personTracing) Update(ctx context.Context, request model.UpdatePerson) (model.Person, error) {
+	ctx, span := p.tracer.Start(ctx, "usecase.Person/Update", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("person.id", request.ID)
+
+	updated, err := p.next.Update(ctx, request)
+	span.RecordError(err)
+	return updated, err
+}
+
+func (p *personTracing) Delete(ctx context.Context, id uint64) error {
+	ctx, span := p.tracer.Start(ctx, "usecase.Person/Delete", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("person.id", id)
+
+	err := p.next.Delete(ctx, id)
+	span.RecordError(err)
+	return err
+}
+
+func (p *personTracing) GetSongs(ctx context.Context, request model.PersonSongsRequest) ([]model.Song, error) {
+	ctx, span := p.tracer.Start(ctx, "usecase.Person/GetSongs", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("person.id", request.PersonID)
+
+	songs, err := p.next.GetSongs(ctx, request)
+	span.RecordError(err)
+	return songs, err
+}
+
+func (p *personTracing) GetCredits(ctx context.Context, songID uint64) ([]model.PersonCredit, error) {
+	ctx, span := p.tracer.Start(ctx, "usecase.Person/GetCredits", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("song.id", songID)
+
+	credits, err := p.next.GetCredits(ctx, songID)
+	span.RecordError(err)
+	return credits, err
+}
+
+func (p *personTracing) SetCredits(ctx context.Context, request model.SetSongCredits) ([]model.PersonCredit, error) {
+	ctx, span := p.tracer.Start(ctx, "usecase.Person/SetCredits", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("song.id", request.SongID)
+
+	credits, err := p.next.SetCredits(ctx, request)
+	span.RecordError(err)
+	return credits, err
+}
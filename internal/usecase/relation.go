@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Relation struct {
+	relationRepo repository.Relation
+	log          *logrus.Logger
+}
+
+func NewRelation(relationRepo repository.Relation, log *logrus.Logger) *Relation {
+	return &Relation{
+		relationRepo: relationRepo,
+		log:          log,
+	}
+}
+
+func (r *Relation) List(ctx context.Context, request model.SongRelationsRequest) ([]model.SongRelation, error) {
+	log := r.log.WithContext(ctx).WithField("op", "internal/usecase/relation/List")
+
+	if err := validateSongRelationsRequest(request); err != nil {
+		log.Warn(err)
+		return nil, err
+	}
+
+	if request.Depth == 0 {
+		request.Depth = 1
+	}
+
+	relations, err := r.relationRepo.List(ctx, request)
+	if err != nil {
+		log.Warn(err)
+		return nil, err
+	}
+
+	log.Infof("Successfully retrieved %d relations for song ID: %d", len(relations), request.SongID)
+	return relations, nil
+}
+
+func (r *Relation) Add(ctx context.Context, request model.AddSongRelation) (model.SongRelation, error) {
+	log := r.log.WithContext(ctx).WithField("op", "internal/usecase/relation/Add")
+
+	if err := validateAddSongRelation(request); err != nil {
+		log.Warn(err)
+		return model.SongRelation{}, err
+	}
+
+	relation, err := r.relationRepo.Add(ctx, request)
+	if err != nil {
+		log.Warn(err)
+		return model.SongRelation{}, err
+	}
+
+	log.Infof("Song ID %d is now %s song ID %d", request.SongID, request.Type, request.RelatedSongID)
+	return relation, nil
+}
+
+func (r *Relation) Delete(ctx context.Context, request model.DeleteSongRelation) error {
+	log := r.log.WithContext(ctx).WithField("op", "internal/usecase/relation/Delete")
+
+	if err := validateDeleteSongRelation(request); err != nil {
+		log.Warn(err)
+		return err
+	}
+
+	if err := r.relationRepo.Delete(ctx, request); err != nil {
+		log.Warn(err)
+		return err
+	}
+
+	log.Infof("Song ID %d is no longer %s song ID %d", request.SongID, request.Type, request.RelatedSongID)
+	return nil
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/usecase"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// relationMetrics counts errors returned by each usecase.Relation method.
+type relationMetrics struct {
+	next   usecase.Relation
+	errors *prometheus.CounterVec
+}
+
+func newRelationMetrics(next usecase.Relation, errors *prometheus.CounterVec) *relationMetrics {
+	return &relationMetrics{
+		next:   next,
+		errors: errors,
+	}
+}
+
+func (r *relationMetrics) observe(method string, err error) {
+	if err != nil {
+		r.errors.WithLabelValues("relation", method, errorKind(err)).Inc()
+	}
+}
+
+func (r *relationMetrics) List(ctx context.Context, request model.SongRelationsRequest) ([]model.SongRelation, error) {
+	relations, err := r.next.List(ctx, request)
+	r.observe("List", err)
+	return relations, err
+}
+
+func (r *relationMetrics) Add(ctx context.Context, request model.AddSongRelation) (model.SongRelation, error) {
+	relation, err := r.next.Add(ctx, request)
+	r.observe("Add", err)
+	return relation, err
+}
+
+func (r *relationMetrics) Delete(ctx context.Context, request model.DeleteSongRelation) error {
+	err := r.next.Delete(ctx, request)
+	r.observe("Delete", err)
+	return err
+}
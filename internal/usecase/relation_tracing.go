@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/usecase"
+	"song_lib/internal/tracing"
+)
+
+// relationTracing wraps every usecase.Relation method in a span.
+type relationTracing struct {
+	next   usecase.Relation
+	tracer *tracing.Tracer
+}
+
+func newRelationTracing(next usecase.Relation, tracer *tracing.Tracer) *relationTracing {
+	return &relationTracing{
+		next:   next,
+		tracer: tracer,
+	}
+}
+
+func (r *relationTracing) List(ctx context.Context, request model.SongRelationsRequest) ([]model.SongRelation, error) {
+	ctx, span := r.tracer.Start(ctx, "usecase.Relation/List", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("song.id", request.SongID)
+
+	relations, err := r.next.List(ctx, request)
+	span.RecordError(err)
+	return relations, err
+}
+
+func (r *relationTracing) Add(ctx context.Context, request model.AddSongRelation) (model.SongRelation, error) {
+	ctx, span := r.tracer.Start(ctx, "usecase.Relation/Add", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("song.id", request.SongID)
+	span.SetAttribute("relation.type", request.Type)
+
+	relation, err := r.next.Add(ctx, request)
+	span.RecordError(err)
+	return relation, err
+}
+
+func (r *relationTracing) Delete(ctx context.Context, request model.DeleteSongRelation) error {
+	ctx, span := r.tracer.Start(ctx, "usecase.Relation/Delete", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("song.id", request.SongID)
+	span.SetAttribute("relation.type", request.Type)
+
+	err := r.next.Delete(ctx, request)
+	span.RecordError(err)
+	return err
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"song_lib/internal/domain/model"
 	"song_lib/internal/domain/repository"
 
@@ -10,29 +11,33 @@ import (
 )
 
 type Song struct {
-	songRepo repository.Song
-	log      *logrus.Logger
+	songRepo        repository.Song
+	maxBulkAffected int
+	log             *logrus.Logger
 }
 
-func NewSong(songRepo repository.Song, log *logrus.Logger) *Song {
+func NewSong(songRepo repository.Song, maxBulkAffected int, log *logrus.Logger) *Song {
 	return &Song{
-		songRepo: songRepo,
-		log:      log,
+		songRepo:        songRepo,
+		maxBulkAffected: maxBulkAffected,
+		log:             log,
 	}
 }
 
 func (s *Song) GetLib(ctx context.Context, request model.LibraryFilter) ([]model.SongDetails, error) {
-	log := s.log.WithField("op", "internal/usecase/song/GetLib")
+	log := s.log.WithContext(ctx).WithField("op", "internal/usecase/song/GetLib")
 
 	log.Debugf("Received request: %+v", request)
 
-	if request.PerPage <= 0 {
-		request.PerPage = 10
-		log.Infof("PerPage was set to default value: %d", request.PerPage)
+	request.Tags = normalizeTags(request.Tags)
+	if err := validateLibraryFilter(request); err != nil {
+		log.Warn(err)
+		return nil, err
 	}
-	if request.Page < 0 {
-		request.Page = 0
-		log.Infof("Page was set to default value: %d", request.Page)
+
+	if request.PerPage == 0 {
+		request.PerPage = defaultPerPage
+		log.Infof("PerPage was set to default value: %d", request.PerPage)
 	}
 
 	songs, err := s.songRepo.GetSongs(ctx, request)
@@ -45,21 +50,34 @@ func (s *Song) GetLib(ctx context.Context, request model.LibraryFilter) ([]model
 	return songs, nil
 }
 
+func (s *Song) Get(ctx context.Context, id uint64) (model.Song, error) {
+	log := s.log.WithContext(ctx).WithField("op", "internal/usecase/song/Get")
+
+	song, err := s.songRepo.Get(ctx, id)
+	if err != nil {
+		log.Warn(err)
+		return model.Song{}, err
+	}
+
+	return song, nil
+}
+
 func (s *Song) GetVerses(ctx context.Context, request model.VersesRequest) (model.VersesResponse, error) {
-	log := s.log.WithField("op", "internal/usecase/song/GetVerses")
+	log := s.log.WithContext(ctx).WithField("op", "internal/usecase/song/GetVerses")
 
 	log.Debugf("Received request: %+v", request)
 
-	if request.PerPage <= 0 {
-		request.PerPage = 10
-		log.Infof("PerPage was set to default value: %d", request.PerPage)
+	if err := validateVersesRequest(request); err != nil {
+		log.Warn(err)
+		return model.VersesResponse{}, err
 	}
-	if request.Page < 0 {
-		request.Page = 0
-		log.Infof("Page was set to default value: %d", request.Page)
+
+	if request.PerPage == 0 {
+		request.PerPage = defaultPerPage
+		log.Infof("PerPage was set to default value: %d", request.PerPage)
 	}
 
-	verses, err := s.songRepo.GetVerses(ctx, request)
+	verses, updatedAt, err := s.songRepo.GetVerses(ctx, request)
 	if err != nil {
 		log.Error(err)
 		return model.VersesResponse{}, err
@@ -68,15 +86,16 @@ func (s *Song) GetVerses(ctx context.Context, request model.VersesRequest) (mode
 	log.Infof("Successfully retrieved %d verses for SongID: %d", len(verses), request.SongID)
 
 	return model.VersesResponse{
-		SongID:  request.SongID,
-		Page:    request.Page,
-		PerPage: request.PerPage,
-		Verses:  verses,
+		SongID:    request.SongID,
+		Page:      request.Page,
+		PerPage:   request.PerPage,
+		Verses:    verses,
+		UpdatedAt: updatedAt,
 	}, nil
 }
 
 func (s *Song) Delete(ctx context.Context, id uint64) error {
-	log := s.log.WithField("op", "internal/usecase/song/Delete")
+	log := s.log.WithContext(ctx).WithField("op", "internal/usecase/song/Delete")
 
 	log.Infof("Attempting to delete song with ID: %d", id)
 
@@ -90,12 +109,11 @@ func (s *Song) Delete(ctx context.Context, id uint64) error {
 }
 
 func (s *Song) Update(ctx context.Context, song model.UpdateSong) (model.Song, error) {
-	log := s.log.WithField("op", "internal/usecase/song/Update")
+	log := s.log.WithContext(ctx).WithField("op", "internal/usecase/song/Update")
 
-	if song == (model.UpdateSong{}) {
-		err := errors.New("No change")
+	if err := validateUpdateSong(song); err != nil {
 		log.Warn(err)
-		return model.Song{}, nil
+		return model.Song{}, err
 	}
 
 	sng := model.Song{
@@ -106,6 +124,9 @@ func (s *Song) Update(ctx context.Context, song model.UpdateSong) (model.Song, e
 		Link:        song.Link,
 		Text:        song.Text,
 	}
+	if song.Artists != nil {
+		sng.Artists = artistCredits(*song.Artists)
+	}
 
 	log.Infof("Updating song with ID: %d", sng.ID)
 
@@ -120,16 +141,22 @@ func (s *Song) Update(ctx context.Context, song model.UpdateSong) (model.Song, e
 }
 
 func (s *Song) Add(ctx context.Context, request model.AddSong) (uint64, error) {
-	log := s.log.WithField("op", "internal/usecase/song/Add")
+	log := s.log.WithContext(ctx).WithField("op", "internal/usecase/song/Add")
 
 	log.Debugf("Received request to add song: %+v", request)
 
+	if err := validateAddSong(request); err != nil {
+		log.Warn(err)
+		return 0, err
+	}
+
 	song := model.Song{
 		Group:       request.Group,
 		Song:        request.Song,
 		ReleaseDate: request.ReleaseDate,
 		Link:        request.Link,
 		Text:        request.Text,
+		Artists:     artistCredits(request.Artists),
 	}
 
 	log.Infof("Attempting to add song: %s by group: %s", song.Song, song.Group)
@@ -143,3 +170,91 @@ func (s *Song) Add(ctx context.Context, request model.AddSong) (uint64, error) {
 	log.Infof("Successfully added song with ID: %d", id)
 	return id, nil
 }
+
+func (s *Song) GetChanges(ctx context.Context, request model.ChangesRequest) (model.SongChanges, error) {
+	log := s.log.WithContext(ctx).WithField("op", "internal/usecase/song/GetChanges")
+
+	log.Debugf("Received request: %+v", request)
+
+	since, err := validateChangesRequest(request)
+	if err != nil {
+		log.Warn(err)
+		return model.SongChanges{}, err
+	}
+
+	if request.Limit == 0 {
+		request.Limit = defaultChangesLimit
+		log.Infof("Limit was set to default value: %d", request.Limit)
+	}
+
+	changes, err := s.songRepo.GetChanges(ctx, since, request.Limit)
+	if err != nil {
+		log.Error(err)
+		return model.SongChanges{}, err
+	}
+
+	log.Infof("Successfully retrieved %d upserts and %d deletions", len(changes.Upserts), len(changes.Deletions))
+
+	return model.SongChanges{
+		Upserts:   changes.Upserts,
+		Deletions: changes.Deletions,
+		NextToken: encodeSyncToken(changes.Last),
+		HasMore:   changes.HasMore,
+	}, nil
+}
+
+func (s *Song) Bulk(ctx context.Context, request model.BulkRequest) (model.BulkResult, error) {
+	log := s.log.WithContext(ctx).WithField("op", "internal/usecase/song/Bulk")
+
+	log.Debugf("Received bulk request: %+v", request)
+
+	request.Filter.Tags = normalizeTags(request.Filter.Tags)
+	if err := validateBulkRequest(request); err != nil {
+		log.Warn(err)
+		return model.BulkResult{}, err
+	}
+
+	op := model.BulkOperation{
+		Operation: request.Operation,
+		Filter:    request.Filter,
+		Field:     request.Field,
+		Value:     request.Value,
+		DryRun:    request.DryRun,
+	}
+	if request.Operation == model.BulkRenameGroup {
+		op.Field = "group"
+		op.Value = request.NewGroup
+	}
+	if !request.Force {
+		op.MaxAffected = s.maxBulkAffected
+	}
+	op.MaxReportedIDs = maxBulkReportedIDs
+
+	result, err := s.songRepo.Bulk(ctx, op)
+	if errors.Is(err, model.ErrBulkLimitExceeded) {
+		return model.BulkResult{}, &model.ValidationError{
+			Errors: []model.FieldError{{
+				Field:   "force",
+				Message: fmt.Sprintf("operation matches %d songs, more than the limit of %d; retry with force=true", result.Matched, s.maxBulkAffected),
+			}},
+		}
+	}
+	if err != nil {
+		log.Error(err)
+		return model.BulkResult{}, err
+	}
+
+	log.Infof("Bulk %s matched %d songs and changed %d", result.Operation, result.Matched, result.Affected)
+	return result, nil
+}
+
+// artistCredits converts requested credits for the repository, never
+// returning nil so an empty list still replaces existing credits.
+func artistCredits(credits []model.AddCredit) []model.ArtistCredit {
+	artists := make([]model.ArtistCredit, 0, len(credits))
+	for _, c := range credits {
+		artists = append(artists, model.ArtistCredit{Name: c.Name, Role: c.Role})
+	}
+
+	return artists
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/usecase"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// songMetrics counts errors returned by each usecase.Song method.
+type songMetrics struct {
+	next   usecase.Song
+	errors *prometheus.CounterVec
+}
+
+func newSongMetrics(next usecase.Song, errors *prometheus.CounterVec) *songMetrics {
+	return &songMetrics{
+		next:   next,
+		errors: errors,
+	}
+}
+
+func (s *songMetrics) observe(method string, err error) {
+	if err != nil {
+		s.errors.WithLabelValues("song", method, errorKind(err)).Inc()
+	}
+}
+
+func (s *songMetrics) GetLib(ctx context.Context, request model.LibraryFilter) ([]model.SongDetails, error) {
+	songs, err := s.next.GetLib(ctx, request)
+	s.observe("GetLib", err)
+	return songs, err
+}
+
+func (s *songMetrics) Get(ctx context.Context, id uint64) (model.Song, error) {
+	song, err := s.next.Get(ctx, id)
+	s.observe("Get", err)
+	return song, err
+}
+
+func (s *songMetrics) GetVerses(ctx context.Context, request model.VersesRequest) (model.VersesResponse, error) {
+	verses, err := s.next.GetVerses(ctx, request)
+	s.observe("GetVerses", err)
+	return verses, err
+}
+
+func (s *songMetrics) Delete(ctx context.Context, id uint64) error {
+	err := s.next.Delete(ctx, id)
+	s.observe("Delete", err)
+	return err
+}
+
+func (s *songMetrics) Update(ctx context.Context, song model.UpdateSong) (model.Song, error) {
+	updated, err := s.next.Update(ctx, song)
+	s.observe("Update", err)
+	return updated, err
+}
+
+func (s *songMetrics) Add(ctx context.Context, request model.AddSong) (uint64, error) {
+	id, err := s.next.Add(ctx, request)
+	s.observe("Add", err)
+	return id, err
+}
+
+func (s *songMetrics) GetChanges(ctx context.Context, request model.ChangesRequest) (model.SongChanges, error) {
+	changes, err := s.next.GetChanges(ctx, request)
+	s.observe("GetChanges", err)
+	return changes, err
+}
+
+func (s *songMetrics) Bulk(ctx context.Context, request model.BulkRequest) (model.BulkResult, error) {
+	result, err := s.next.Bulk(ctx, request)
+	s.observe("Bulk", err)
+	return result, err
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/usecase"
+	"song_lib/internal/tracing"
+)
+
+// songTracing wraps every usecase.Song method in a span.
+type songTracing struct {
+	next   usecase.Song
+	tracer *tracing.Tracer
+}
+
+func newSongTracing(next usecase.Song, tracer *tracing.Tracer) *songTracing {
+	return &songTracing{
+		next:   next,
+		tracer: tracer,
+	}
+}
+
+func (s *songTracing) GetLib(ctx context.Context, request model.LibraryFilter) ([]model.SongDetails, error) {
+	ctx, span := s.tracer.Start(ctx, "usecase.Song/GetLib", tracing.SpanKindInternal)
+	defer span.End()
+
+	songs, err := s.next.GetLib(ctx, request)
+	span.RecordError(err)
+	return songs, err
+}
+
+func (s *songTracing) GetVerses(ctx context.Context, request model.VersesRequest) (model.VersesResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "usecase.Song/GetVerses", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("song.id", request.SongID)
+
+	verses, err := s.next.GetVerses(ctx, request)
+	span.RecordError(err)
+	return verses, err
+}
+
+func (s *songTracing) Get(ctx context.Context, id uint64) (model.Song, error) {
+	ctx, span := s.tracer.Start(ctx, "usecase.Song/Get", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("song.id", id)
+
+	song, err := s.next.Get(ctx, id)
+	span.RecordError(err)
+	return song, err
+}
+
+func (s *songTracing) Delete(ctx context.Context, id uint64) error {
+	ctx, span := s.tracer.Start(ctx, "usecase.Song/Delete", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("song.id", id)
+
+	err := s.next.Delete(ctx, id)
+	span.RecordError(err)
+	return err
+}
+
+func (s *songTracing) Update(ctx context.Context, song model.UpdateSong) (model.Song, error) {
+	ctx, span := s.tracer.Start(ctx, "usecase.Song/Update", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("song.id", song.ID)
+
+	updated, err := s.next.Update(ctx, song)
+	span.RecordError(err)
+	return updated, err
+}
+
+func (s *songTracing) Add(ctx context.Context, request model.AddSong) (uint64, error) {
+	ctx, span := s.tracer.Start(ctx, "usecase.Song/Add", tracing.SpanKindInternal)
+	defer span.End()
+
+	id, err := s.next.Add(ctx, request)
+	span.RecordError(err)
+	return id, err
+}
+
+func (s *songTracing) GetChanges(ctx context.Context, request model.ChangesRequest) (model.SongChanges, error) {
+	ctx, span := s.tracer.Start(ctx, "usecase.Song/GetChanges", tracing.SpanKindInternal)
+	defer span.End()
+
+	changes, err := s.next.GetChanges(ctx, request)
+	span.RecordError(err)
+	return changes, err
+}
+
+func (s *songTracing) Bulk(ctx context.Context, request model.BulkRequest) (model.BulkResult, error) {
+	ctx, span := s.tracer.Start(ctx, "usecase.Song/Bulk", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("bulk.operation", request.Operation)
+
+	result, err := s.next.Bulk(ctx, request)
+	span.RecordError(err)
+	return result, err
+}
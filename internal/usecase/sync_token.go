@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"errors"
+	"song_lib/internal/domain/model"
+	"strconv"
+	"strings"
+)
+
+// v1 tokens held a bare sequence number, which cannot be placed in the
+// (xid, seq) order of the feed, so they are no longer accepted.
+const syncTokenPrefix = "v2:"
+
+// Sync tokens are opaque to clients so the cursor can change later without
+// breaking them.
+
+func encodeSyncToken(cursor model.ChangeCursor) string {
+	raw := syncTokenPrefix + strconv.FormatUint(cursor.XID, 10) + "." + strconv.FormatUint(cursor.Seq, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeSyncToken(token string) (model.ChangeCursor, error) {
+	if token == "" {
+		return model.ChangeCursor{}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return model.ChangeCursor{}, errors.New("malformed sync token")
+	}
+
+	cursor, found := strings.CutPrefix(string(raw), syncTokenPrefix)
+	if !found {
+		return model.ChangeCursor{}, errors.New("unsupported sync token version")
+	}
+
+	xid, seq, found := strings.Cut(cursor, ".")
+	if !found {
+		return model.ChangeCursor{}, errors.New("malformed sync token")
+	}
+
+	var c model.ChangeCursor
+	if c.XID, err = strconv.ParseUint(xid, 10, 64); err != nil {
+		return model.ChangeCursor{}, errors.New("malformed sync token")
+	}
+	if c.Seq, err = strconv.ParseUint(seq, 10, 64); err != nil {
+		return model.ChangeCursor{}, errors.New("malformed sync token")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"math"
+	"song_lib/internal/domain/model"
+	"testing"
+)
+
+func TestSyncTokenRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor model.ChangeCursor
+	}{
+		{name: "zero", cursor: model.ChangeCursor{}},
+		{name: "typical", cursor: model.ChangeCursor{XID: 7421, Seq: 98}},
+		{name: "maximum", cursor: model.ChangeCursor{XID: math.MaxUint64, Seq: math.MaxUint64}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := encodeSyncToken(tt.cursor)
+
+			got, err := decodeSyncToken(token)
+			if err != nil {
+				t.Fatalf("decodeSyncToken(%q) error = %v", token, err)
+			}
+			if got != tt.cursor {
+				t.Errorf("decodeSyncToken(%q) = %+v, want %+v", token, got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeSyncToken(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    model.ChangeCursor
+		wantErr string
+	}{
+		{name: "empty starts from the beginning", token: "", want: model.ChangeCursor{}},
+		{name: "valid", token: encode("v2:10.3"), want: model.ChangeCursor{XID: 10, Seq: 3}},
+		{name: "not base64", token: "!!!", wantErr: "malformed sync token"},
+		{name: "padded base64", token: base64.URLEncoding.EncodeToString([]byte("v2:10.3")), wantErr: "malformed sync token"},
+		{name: "v1 token", token: encode("v1:42"), wantErr: "unsupported sync token version"},
+		{name: "no version", token: encode("10.3"), wantErr: "unsupported sync token version"},
+		{name: "missing seq", token: encode("v2:10"), wantErr: "malformed sync token"},
+		{name: "non-numeric xid", token: encode("v2:x.3"), wantErr: "malformed sync token"},
+		{name: "negative seq", token: encode("v2:10.-3"), wantErr: "malformed sync token"},
+		{name: "extra field", token: encode("v2:10.3.1"), wantErr: "malformed sync token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeSyncToken(tt.token)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("decodeSyncToken(%q) error = %v, want %q", tt.token, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeSyncToken(%q) error = %v", tt.token, err)
+			}
+			if got != tt.want {
+				t.Errorf("decodeSyncToken(%q) = %+v, want %+v", tt.token, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/repository"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Tag struct {
+	tagRepo repository.Tag
+	log     *logrus.Logger
+}
+
+func NewTag(tagRepo repository.Tag, log *logrus.Logger) *Tag {
+	return &Tag{
+		tagRepo: tagRepo,
+		log:     log,
+	}
+}
+
+// normalizeTag makes tags case-insensitive and ignores surrounding space.
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// normalizeTags normalizes each tag, dropping blanks and repeats.
+func normalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	return normalized
+}
+
+func (t *Tag) List(ctx context.Context, request model.TagsRequest) ([]model.TagCount, error) {
+	log := t.log.WithContext(ctx).WithField("op", "internal/usecase/tag/List")
+
+	if err := validateTagsRequest(request); err != nil {
+		log.Warn(err)
+		return nil, err
+	}
+
+	if request.PerPage == 0 {
+		request.PerPage = defaultPerPage
+		log.Infof("PerPage was set to default value: %d", request.PerPage)
+	}
+
+	tags, err := t.tagRepo.List(ctx, request)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	log.Infof("Successfully retrieved %d tags", len(tags))
+	return tags, nil
+}
+
+func (t *Tag) GetSongTags(ctx context.Context, songID uint64) (model.SongTags, error) {
+	log := t.log.WithContext(ctx).WithField("op", "internal/usecase/tag/GetSongTags")
+
+	tags, err := t.tagRepo.GetSongTags(ctx, songID)
+	if err != nil {
+		log.Warn(err)
+		return model.SongTags{}, err
+	}
+
+	return tags, nil
+}
+
+func (t *Tag) Attach(ctx context.Context, request model.AttachTags) (model.SongTags, error) {
+	log := t.log.WithContext(ctx).WithField("op", "internal/usecase/tag/Attach")
+
+	request.Tags = normalizeTags(request.Tags)
+	if err := validateAttachTags(request); err != nil {
+		log.Warn(err)
+		return model.SongTags{}, err
+	}
+
+	tags, err := t.tagRepo.Attach(ctx, request)
+	if err != nil {
+		log.Warn(err)
+		return model.SongTags{}, err
+	}
+
+	log.Infof("Successfully attached %d tags to song ID: %d", len(request.Tags), request.SongID)
+	return tags, nil
+}
+
+func (t *Tag) Detach(ctx context.Context, request model.DetachTag) (model.SongTags, error) {
+	log := t.log.WithContext(ctx).WithField("op", "internal/usecase/tag/Detach")
+
+	request.Tag = normalizeTag(request.Tag)
+	if err := validateDetachTag(request); err != nil {
+		log.Warn(err)
+		return model.SongTags{}, err
+	}
+
+	tags, err := t.tagRepo.Detach(ctx, request)
+	if err != nil {
+		log.Warn(err)
+		return model.SongTags{}, err
+	}
+
+	log.Infof("Successfully detached tag %q from song ID: %d", request.Tag, request.SongID)
+	return tags, nil
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"song_lib/internal/domain/model"
+	"song_lib/internal/domain/usecase"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// tagMetrics counts errors returned by each usecase.Tag method.
+type tagMetrics struct {
+	next   usecase.Tag
+	errors *prometheus.CounterVec
+}
+
+func newTagMetrics(next usecase.Tag, errors *prometheus.CounterVec) *tagMetrics {
+	return &tagMetrics{
+		next:   next,
+		errors: errors,
+	}
+}
+
+func (t *tagMetrics) observe(method string, err error) {
+	if err != nil {
+		t.errors.WithLabelValues("tag", method, errorKind(err)).Inc()
+	}
+}
+
+func (t *tagMetrics) List(ctx context.Context, request model.TagsRequest) ([]model.TagCount, error) {
+	tags, err := t.next.List(ctx, request)
+	t.observe("List", err)
+	return tags, err
+}
+
+func (t *tagMetrics) GetSongTags(ctx context.Context, songID uint64) (model.SongTags, error) {
+	tags, err := t.next.GetSongTags(ctx, songID)
+	t.observe("GetSongTags", err)
+	return tags, err
+}
+
+func (t *tagMetrics) Attach(ctx context.Context, request model.AttachTags) (model.SongTags, error) {
+	tags, err := t.next.Attach(ctx, request)
+	t.observe("Attach", err)
+	return tags, err
+}
+
+func (t *tagMetrics) Detach(ctx context.Context, request model.DetachTag) (model.SongTags, error) {
+	tags, err := t.next.Detach(ctx, request)
+	t.observe("Detach", err)
+	return tags, err
+}
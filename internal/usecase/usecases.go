@@ -1,18 +1,37 @@
 package usecase
 
 import (
+	"song_lib/internal/config"
 	"song_lib/internal/domain/usecase"
+	"song_lib/internal/metrics"
 	"song_lib/internal/repository"
+	"song_lib/internal/tracing"
 
 	"github.com/sirupsen/logrus"
 )
 
 type Usecases struct {
 	usecase.Song
+	usecase.Group
+	usecase.Album
+	usecase.Person
+	usecase.Tag
+	usecase.Relation
+	usecase.Health
+	usecase.Idempotency
+	usecase.Maintenance
 }
 
-func NewUsecases(repos *repository.Repositories, log *logrus.Logger) *Usecases {
+func NewUsecases(cfg *config.Config, repos *repository.Repositories, log *logrus.Logger, m *metrics.Metrics, tracer *tracing.Tracer) *Usecases {
 	return &Usecases{
-		Song: NewSong(repos.Song, log),
+		Song:        newSongMetrics(newSongTracing(NewSong(repos.Song, cfg.Bulk.MaxAffected, log), tracer), m.UsecaseErrors),
+		Group:       newGroupMetrics(newGroupTracing(NewGroup(repos.Group, log), tracer), m.UsecaseErrors),
+		Album:       newAlbumMetrics(newAlbumTracing(NewAlbum(repos.Album, log), tracer), m.UsecaseErrors),
+		Person:      newPersonMetrics(newPersonTracing(NewPerson(repos.Person, log), tracer), m.UsecaseErrors),
+		Tag:         newTagMetrics(newTagTracing(NewTag(repos.Tag, log), tracer), m.UsecaseErrors),
+		Relation:    newRelationMetrics(newRelationTracing(NewRelation(repos.Relation, log), tracer), m.UsecaseErrors),
+		Health:      NewHealth(repos.Health, log),
+		Idempotency: NewIdempotency(repos.Idempotency, cfg.Idempotency.TTL, log),
+		Maintenance: NewMaintenance(cfg.Maintenance.ReadOnly, log),
 	}
 }
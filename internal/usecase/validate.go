@@ -0,0 +1,588 @@
+package usecase
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"song_lib/internal/domain/model"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	maxFieldLength      = 255 // VARCHAR(255) columns
+	maxTextLength       = 20000
+	maxPerPage          = 100
+	maxPage             = 100000
+	defaultPerPage      = 10
+	maxChangesLimit     = 1000
+	defaultChangesLimit = 100
+	releaseDateForm     = "02.01.2006"
+	minFormedYear       = 1000
+	maxAlbumTracks      = 500
+	maxCredits          = 50
+	maxBulkReportedIDs  = 1000
+	maxTagLength        = 64 // VARCHAR(64) column
+	maxTags             = 20
+	maxRelationDepth    = 5
+)
+
+type validator struct {
+	errors []model.FieldError
+}
+
+func (v *validator) add(field, format string, args ...any) {
+	v.errors = append(v.errors, model.FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) err() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+
+	return &model.ValidationError{Errors: v.errors}
+}
+
+func (v *validator) required(field, value string) bool {
+	if value == "" {
+		v.add(field, "is required")
+		return false
+	}
+
+	return true
+}
+
+func (v *validator) maxLen(field, value string, max int) {
+	if n := utf8.RuneCountInString(value); n > max {
+		v.add(field, "must be at most %d characters, got %d", max, n)
+	}
+}
+
+func (v *validator) releaseDate(field, value string) {
+	if _, err := time.Parse(releaseDateForm, value); err != nil {
+		v.add(field, "must be a date in DD.MM.YYYY format")
+	}
+}
+
+func (v *validator) link(field, value string) {
+	v.maxLen(field, value, maxFieldLength)
+
+	u, err := url.ParseRequestURI(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add(field, "must be an absolute http or https URL")
+	}
+}
+
+func (v *validator) pagination(page, perPage int) {
+	if page < 0 || page > maxPage {
+		v.add("page", "must be between 0 and %d", maxPage)
+	}
+	if perPage < 0 || perPage > maxPerPage {
+		v.add("per_page", "must be between 1 and %d, or 0 for the default", maxPerPage)
+	}
+}
+
+func validateAddSong(request model.AddSong) error {
+	v := &validator{}
+
+	if v.required("song", request.Song) {
+		v.maxLen("song", request.Song, maxFieldLength)
+	}
+	if v.required("group", request.Group) {
+		v.maxLen("group", request.Group, maxFieldLength)
+	}
+	if v.required("releaseDate", request.ReleaseDate) {
+		v.releaseDate("releaseDate", request.ReleaseDate)
+	}
+	if v.required("link", request.Link) {
+		v.link("link", request.Link)
+	}
+	if v.required("text", request.Text) {
+		v.maxLen("text", request.Text, maxTextLength)
+	}
+	v.credits("artists", request.Group, request.Artists)
+
+	return v.err()
+}
+
+func validateUpdateSong(song model.UpdateSong) error {
+	v := &validator{}
+
+	if song == (model.UpdateSong{ID: song.ID}) {
+		v.add("body", "at least one field must be provided")
+	}
+
+	v.maxLen("song", song.Song, maxFieldLength)
+	v.maxLen("group", song.Group, maxFieldLength)
+	if song.ReleaseDate != "" {
+		v.releaseDate("releaseDate", song.ReleaseDate)
+	}
+	if song.Link != "" {
+		v.link("link", song.Link)
+	}
+	v.maxLen("text", song.Text, maxTextLength)
+	if song.Artists != nil {
+		v.credits("artists", song.Group, *song.Artists)
+	}
+
+	return v.err()
+}
+
+// credits checks credited artists besides the song's own group, which may
+// be empty when it is not being changed.
+func (v *validator) credits(field, group string, credits []model.AddCredit) {
+	if len(credits) > maxCredits {
+		v.add(field, "must have at most %d artists, got %d", maxCredits, len(credits))
+	}
+
+	seen := make(map[string]bool, len(credits))
+	for i, c := range credits {
+		name := fmt.Sprintf("%s[%d].name", field, i)
+		if v.required(name, c.Name) {
+			v.maxLen(name, c.Name, maxFieldLength)
+		}
+		if c.Name != "" && (seen[c.Name] || c.Name == group) {
+			v.add(name, "%q is already credited", c.Name)
+		}
+		seen[c.Name] = true
+
+		if !slices.Contains(model.CreditRoles, c.Role) {
+			v.add(fmt.Sprintf("%s[%d].role", field, i), "must be one of %v", model.CreditRoles)
+		}
+	}
+}
+
+// people checks the people filters in a stable order.
+func (v *validator) people(prefix string, people map[string]string) {
+	for _, role := range model.PersonRoles {
+		v.maxLen(prefix+role, people[role], maxFieldLength)
+	}
+}
+
+func validateLibraryFilter(filter model.LibraryFilter) error {
+	v := &validator{}
+
+	v.pagination(filter.Page, filter.PerPage)
+	v.maxLen("group", filter.Group, maxFieldLength)
+	v.maxLen("song", filter.Song, maxFieldLength)
+	v.maxLen("album", filter.Album, maxFieldLength)
+	v.people("", filter.People())
+	v.tags("tags", filter.Tags)
+	v.tagsMatch("tagsMatch", filter.TagsMatch)
+
+	return v.err()
+}
+
+var bulkFields = []string{"song", "group", "releaseDate", "link", "text"}
+
+func validateBulkRequest(request model.BulkRequest) error {
+	v := &validator{}
+
+	if request.Filter.Empty() {
+		v.add("filter", "must set at least one of group, song, album, tags or a people filter")
+	}
+	if request.Filter.Page != 0 || request.Filter.PerPage != 0 {
+		v.add("filter", "page and per_page are not supported for bulk operations")
+	}
+	v.maxLen("filter.group", request.Filter.Group, maxFieldLength)
+	v.maxLen("filter.song", request.Filter.Song, maxFieldLength)
+	v.maxLen("filter.album", request.Filter.Album, maxFieldLength)
+	v.people("filter.", request.Filter.People())
+	v.tags("filter.tags", request.Filter.Tags)
+	v.tagsMatch("filter.tagsMatch", request.Filter.TagsMatch)
+
+	switch request.Operation {
+	case model.BulkDelete:
+	case model.BulkSetField:
+		v.bulkValue(request.Field, request.Value)
+	case model.BulkRenameGroup:
+		if request.Filter.Group == "" {
+			v.add("filter.group", "is required for rename-group")
+		}
+		if v.required("newGroup", request.NewGroup) {
+			v.maxLen("newGroup", request.NewGroup, maxFieldLength)
+		}
+	default:
+		v.add("operation", "must be one of %s, %s, %s", model.BulkDelete, model.BulkSetField, model.BulkRenameGroup)
+	}
+
+	return v.err()
+}
+
+// bulkValue checks a set-field value with the same rules as the field has
+// when adding a song.
+func (v *validator) bulkValue(field, value string) {
+	switch field {
+	case "song", "group":
+		if v.required("value", value) {
+			v.maxLen("value", value, maxFieldLength)
+		}
+	case "releaseDate":
+		if v.required("value", value) {
+			v.releaseDate("value", value)
+		}
+	case "link":
+		if v.required("value", value) {
+			v.link("value", value)
+		}
+	case "text":
+		if v.required("value", value) {
+			v.maxLen("value", value, maxTextLength)
+		}
+	default:
+		v.add("field", "must be one of %v", bulkFields)
+	}
+}
+
+func (v *validator) formedYear(field string, year *int) {
+	if year == nil {
+		return
+	}
+	if current := time.Now().Year(); *year < minFormedYear || *year > current {
+		v.add(field, "must be between %d and %d", minFormedYear, current)
+	}
+}
+
+func validateAddGroup(request model.AddGroup) error {
+	v := &validator{}
+
+	if v.required("name", request.Name) {
+		v.maxLen("name", request.Name, maxFieldLength)
+	}
+	v.maxLen("country", request.Country, maxFieldLength)
+	v.formedYear("formedYear", request.FormedYear)
+	v.maxLen("description", request.Description, maxTextLength)
+
+	return v.err()
+}
+
+func validateUpdateGroup(request model.UpdateGroup) error {
+	v := &validator{}
+
+	if request == (model.UpdateGroup{ID: request.ID}) {
+		v.add("body", "at least one field must be provided")
+	}
+
+	v.maxLen("name", request.Name, maxFieldLength)
+	v.maxLen("country", request.Country, maxFieldLength)
+	v.formedYear("formedYear", request.FormedYear)
+	v.maxLen("description", request.Description, maxTextLength)
+
+	return v.err()
+}
+
+func validateGroupsRequest(request model.GroupsRequest) error {
+	v := &validator{}
+
+	v.pagination(request.Page, request.PerPage)
+
+	return v.err()
+}
+
+func validateGroupSongsRequest(request model.GroupSongsRequest) error {
+	v := &validator{}
+
+	if request.GroupID == 0 {
+		v.add("id", "is required")
+	}
+	v.pagination(request.Page, request.PerPage)
+
+	return v.err()
+}
+
+func (v *validator) tracks(field string, songIDs []uint64) {
+	if len(songIDs) > maxAlbumTracks {
+		v.add(field, "must have at most %d tracks, got %d", maxAlbumTracks, len(songIDs))
+	}
+
+	seen := make(map[uint64]bool, len(songIDs))
+	for i, id := range songIDs {
+		switch {
+		case id == 0:
+			v.add(fmt.Sprintf("%s[%d]", field, i), "must be a song ID")
+		case seen[id]:
+			v.add(fmt.Sprintf("%s[%d]", field, i), "song %d is already on the album", id)
+		}
+		seen[id] = true
+	}
+}
+
+func validateAddAlbum(request model.AddAlbum) error {
+	v := &validator{}
+
+	if v.required("title", request.Title) {
+		v.maxLen("title", request.Title, maxFieldLength)
+	}
+	if request.GroupID == 0 {
+		v.add("groupId", "is required")
+	}
+	if request.ReleaseDate != "" {
+		v.releaseDate("releaseDate", request.ReleaseDate)
+	}
+	if request.CoverLink != "" {
+		v.link("coverLink", request.CoverLink)
+	}
+	v.tracks("tracks", request.Tracks)
+
+	return v.err()
+}
+
+func validateUpdateAlbum(request model.UpdateAlbum) error {
+	v := &validator{}
+
+	if request == (model.UpdateAlbum{ID: request.ID}) {
+		v.add("body", "at least one field must be provided")
+	}
+
+	v.maxLen("title", request.Title, maxFieldLength)
+	if request.ReleaseDate != "" {
+		v.releaseDate("releaseDate", request.ReleaseDate)
+	}
+	if request.CoverLink != "" {
+		v.link("coverLink", request.CoverLink)
+	}
+
+	return v.err()
+}
+
+func validateSetAlbumTracks(request model.SetAlbumTracks) error {
+	v := &validator{}
+
+	if request.AlbumID == 0 {
+		v.add("id", "is required")
+	}
+	v.tracks("tracks", request.Tracks)
+
+	return v.err()
+}
+
+func validateAlbumsRequest(request model.AlbumsRequest) error {
+	v := &validator{}
+
+	v.pagination(request.Page, request.PerPage)
+
+	return v.err()
+}
+
+// personCredits checks the person credits of a song. A person may hold
+// several roles but each only once.
+func (v *validator) personCredits(field string, credits []model.AddPersonCredit) {
+	if len(credits) > maxCredits {
+		v.add(field, "must have at most %d credits, got %d", maxCredits, len(credits))
+	}
+
+	seen := make(map[model.AddPersonCredit]bool, len(credits))
+	for i, c := range credits {
+		name := fmt.Sprintf("%s[%d].name", field, i)
+		if v.required(name, c.Name) {
+			v.maxLen(name, c.Name, maxFieldLength)
+		}
+		if !slices.Contains(model.PersonRoles, c.Role) {
+			v.add(fmt.Sprintf("%s[%d].role", field, i), "must be one of %v", model.PersonRoles)
+		}
+		if seen[c] {
+			v.add(name, "%q is already credited as %s", c.Name, c.Role)
+		}
+		seen[c] = true
+	}
+}
+
+func validateAddPerson(request model.AddPerson) error {
+	v := &validator{}
+
+	if v.required("name", request.Name) {
+		v.maxLen("name", request.Name, maxFieldLength)
+	}
+
+	return v.err()
+}
+
+func validateUpdatePerson(request model.UpdatePerson) error {
+	v := &validator{}
+
+	if v.required("name", request.Name) {
+		v.maxLen("name", request.Name, maxFieldLength)
+	}
+
+	return v.err()
+}
+
+func validatePeopleRequest(request model.PeopleRequest) error {
+	v := &validator{}
+
+	v.pagination(request.Page, request.PerPage)
+
+	return v.err()
+}
+
+func validatePersonSongsRequest(request model.PersonSongsRequest) error {
+	v := &validator{}
+
+	if request.PersonID == 0 {
+		v.add("id", "is required")
+	}
+	if request.Role != "" && !slices.Contains(model.PersonRoles, request.Role) {
+		v.add("role", "must be one of %v", model.PersonRoles)
+	}
+	v.pagination(request.Page, request.PerPage)
+
+	return v.err()
+}
+
+func validateSetSongCredits(request model.SetSongCredits) error {
+	v := &validator{}
+
+	if request.SongID == 0 {
+		v.add("id", "is required")
+	}
+	v.personCredits("credits", request.Credits)
+
+	return v.err()
+}
+
+// tag checks a normalized tag name: letters without upper case, digits,
+// spaces and hyphens.
+func (v *validator) tag(field, tag string) {
+	if !v.required(field, tag) {
+		return
+	}
+	v.maxLen(field, tag, maxTagLength)
+
+	for _, r := range tag {
+		letter := unicode.IsLetter(r) && !unicode.IsUpper(r)
+		if !letter && !unicode.IsDigit(r) && r != '-' && r != ' ' {
+			v.add(field, "may only contain letters, digits, spaces and hyphens")
+			return
+		}
+	}
+}
+
+func (v *validator) tags(field string, tags []string) {
+	if len(tags) > maxTags {
+		v.add(field, "must have at most %d tags, got %d", maxTags, len(tags))
+	}
+	for i, tag := range tags {
+		v.tag(fmt.Sprintf("%s[%d]", field, i), tag)
+	}
+}
+
+func (v *validator) tagsMatch(field, match string) {
+	if match != "" && match != model.TagsMatchAll && match != model.TagsMatchAny {
+		v.add(field, "must be %s or %s", model.TagsMatchAll, model.TagsMatchAny)
+	}
+}
+
+func validateTagsRequest(request model.TagsRequest) error {
+	v := &validator{}
+
+	v.pagination(request.Page, request.PerPage)
+
+	return v.err()
+}
+
+func validateAttachTags(request model.AttachTags) error {
+	v := &validator{}
+
+	if request.SongID == 0 {
+		v.add("id", "is required")
+	}
+	if len(request.Tags) == 0 {
+		v.add("tags", "is required")
+	}
+	v.tags("tags", request.Tags)
+
+	return v.err()
+}
+
+func validateDetachTag(request model.DetachTag) error {
+	v := &validator{}
+
+	if request.SongID == 0 {
+		v.add("id", "is required")
+	}
+	v.tag("tag", request.Tag)
+
+	return v.err()
+}
+
+func (v *validator) relationType(field, relationType string) {
+	if !slices.Contains(model.RelationTypes, relationType) {
+		v.add(field, "must be one of %v", model.RelationTypes)
+	}
+}
+
+func (v *validator) relatedSongs(songID, relatedSongID uint64) {
+	if songID == 0 {
+		v.add("id", "is required")
+	}
+	if relatedSongID == 0 {
+		v.add("relatedSongId", "is required")
+	} else if relatedSongID == songID {
+		v.add("relatedSongId", "must differ from the song itself")
+	}
+}
+
+func validateSongRelationsRequest(request model.SongRelationsRequest) error {
+	v := &validator{}
+
+	if request.SongID == 0 {
+		v.add("id", "is required")
+	}
+	if request.Type != "" {
+		v.relationType("type", request.Type)
+	}
+	if request.Direction != "" && request.Direction != model.RelationOutgoing && request.Direction != model.RelationIncoming {
+		v.add("direction", "must be %s or %s", model.RelationOutgoing, model.RelationIncoming)
+	}
+	if request.Depth < 0 || request.Depth > maxRelationDepth {
+		v.add("depth", "must be between 1 and %d, or 0 for the default", maxRelationDepth)
+	}
+
+	return v.err()
+}
+
+func validateAddSongRelation(request model.AddSongRelation) error {
+	v := &validator{}
+
+	v.relatedSongs(request.SongID, request.RelatedSongID)
+	v.relationType("type", request.Type)
+
+	return v.err()
+}
+
+func validateDeleteSongRelation(request model.DeleteSongRelation) error {
+	v := &validator{}
+
+	v.relatedSongs(request.SongID, request.RelatedSongID)
+	v.relationType("type", request.Type)
+
+	return v.err()
+}
+
+func validateVersesRequest(request model.VersesRequest) error {
+	v := &validator{}
+
+	if request.SongID == 0 {
+		v.add("id", "is required")
+	}
+	v.pagination(request.Page, request.PerPage)
+
+	return v.err()
+}
+
+// validateChangesRequest also decodes the sync token, returning the cursor
+// it points at.
+func validateChangesRequest(request model.ChangesRequest) (model.ChangeCursor, error) {
+	v := &validator{}
+
+	since, err := decodeSyncToken(request.Since)
+	if err != nil {
+		v.add("since", "is not a valid sync token")
+	}
+	if request.Limit < 0 || request.Limit > maxChangesLimit {
+		v.add("limit", "must be between 1 and %d, or 0 for the default", maxChangesLimit)
+	}
+
+	return since, v.err()
+}
@@ -0,0 +1,380 @@
+package usecase
+
+import (
+	"errors"
+	"reflect"
+	"song_lib/internal/domain/model"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// errorFields returns the fields a validation error complains about, in
+// order, or nil for a nil error.
+func errorFields(t *testing.T, err error) []string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+
+	var verr *model.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("error = %v, want *model.ValidationError", err)
+	}
+
+	fields := make([]string, 0, len(verr.Errors))
+	for _, e := range verr.Errors {
+		fields = append(fields, e.Field)
+	}
+	return fields
+}
+
+func TestValidatePagination(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    int
+		perPage int
+		want    []string
+	}{
+		{name: "defaults", page: 0, perPage: 0},
+		{name: "limits", page: maxPage, perPage: maxPerPage},
+		{name: "negative page", page: -1, perPage: 10, want: []string{"page"}},
+		{name: "page too large", page: maxPage + 1, perPage: 10, want: []string{"page"}},
+		{name: "negative per page", page: 1, perPage: -1, want: []string{"per_page"}},
+		{name: "per page too large", page: 1, perPage: maxPerPage + 1, want: []string{"per_page"}},
+		{name: "both", page: -1, perPage: -1, want: []string{"page", "per_page"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateGroupsRequest(model.GroupsRequest{Page: tt.page, PerPage: tt.perPage})
+			if got := errorFields(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAddSong(t *testing.T) {
+	valid := model.AddSong{
+		Song:        "Supermassive Black Hole",
+		Group:       "Muse",
+		ReleaseDate: "16.07.2006",
+		Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+		Text:        "Ooh baby, don't you know I suffer?",
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(r *model.AddSong)
+		want   []string
+	}{
+		{name: "valid", mutate: func(*model.AddSong) {}},
+		{
+			name:   "all missing",
+			mutate: func(r *model.AddSong) { *r = model.AddSong{} },
+			want:   []string{"song", "group", "releaseDate", "link", "text"},
+		},
+		{name: "song too long", mutate: func(r *model.AddSong) { r.Song = strings.Repeat("я", maxFieldLength+1) }, want: []string{"song"}},
+		{name: "multibyte at the limit", mutate: func(r *model.AddSong) { r.Song = strings.Repeat("я", maxFieldLength) }},
+		{name: "bad release date", mutate: func(r *model.AddSong) { r.ReleaseDate = "2006-07-16" }, want: []string{"releaseDate"}},
+		{name: "relative link", mutate: func(r *model.AddSong) { r.Link = "/watch?v=1" }, want: []string{"link"}},
+		{name: "non-http link", mutate: func(r *model.AddSong) { r.Link = "ftp://example.com/song" }, want: []string{"link"}},
+		{
+			name: "valid artists",
+			mutate: func(r *model.AddSong) {
+				r.Artists = []model.AddCredit{{Name: "Queen", Role: model.CreditFeatured}}
+			},
+		},
+		{
+			name: "bad artists",
+			mutate: func(r *model.AddSong) {
+				r.Artists = []model.AddCredit{
+					{Name: "Muse", Role: model.CreditFeatured},
+					{Name: "Queen", Role: "drummer"},
+					{Name: "Queen", Role: model.CreditFeatured},
+					{Name: "", Role: model.CreditFeatured},
+				}
+			},
+			want: []string{"artists[0].name", "artists[1].role", "artists[2].name", "artists[3].name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := valid
+			tt.mutate(&request)
+
+			if got := errorFields(t, validateAddSong(request)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateUpdateSong(t *testing.T) {
+	noArtists := []model.AddCredit{}
+
+	tests := []struct {
+		name    string
+		request model.UpdateSong
+		want    []string
+	}{
+		{name: "empty body", request: model.UpdateSong{ID: 1}, want: []string{"body"}},
+		{name: "single field", request: model.UpdateSong{ID: 1, Song: "Uprising"}},
+		{name: "clearing artists", request: model.UpdateSong{ID: 1, Artists: &noArtists}},
+		{name: "bad date", request: model.UpdateSong{ID: 1, ReleaseDate: "yesterday"}, want: []string{"releaseDate"}},
+		{name: "bad link", request: model.UpdateSong{ID: 1, Link: "not a link"}, want: []string{"link"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorFields(t, validateUpdateSong(tt.request)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateLibraryFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter model.LibraryFilter
+		want   []string
+	}{
+		{name: "empty", filter: model.LibraryFilter{}},
+		{name: "tags", filter: model.LibraryFilter{Tags: []string{"post-rock", "90s", "ночь"}, TagsMatch: model.TagsMatchAny}},
+		{name: "upper-case tag", filter: model.LibraryFilter{Tags: []string{"Rock"}}, want: []string{"tags[0]"}},
+		{name: "tag with punctuation", filter: model.LibraryFilter{Tags: []string{"rock!"}}, want: []string{"tags[0]"}},
+		{name: "empty tag", filter: model.LibraryFilter{Tags: []string{""}}, want: []string{"tags[0]"}},
+		{name: "too many tags", filter: model.LibraryFilter{Tags: make([]string, maxTags+1)}, want: append([]string{"tags"}, tagFields(maxTags+1)...)},
+		{name: "unknown tags match", filter: model.LibraryFilter{TagsMatch: "some"}, want: []string{"tagsMatch"}},
+		{
+			name:   "people",
+			filter: model.LibraryFilter{Lyricist: strings.Repeat("a", maxFieldLength+1), Arranger: strings.Repeat("a", maxFieldLength+1)},
+			want:   []string{model.PersonLyricist, model.PersonArranger},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorFields(t, validateLibraryFilter(tt.filter)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func tagFields(n int) []string {
+	fields := make([]string, n)
+	for i := range fields {
+		fields[i] = "tags[" + strconv.Itoa(i) + "]"
+	}
+	return fields
+}
+
+func TestValidateBulkRequest(t *testing.T) {
+	byGroup := model.LibraryFilter{Group: "Muse"}
+
+	tests := []struct {
+		name    string
+		request model.BulkRequest
+		want    []string
+	}{
+		{name: "delete", request: model.BulkRequest{Operation: model.BulkDelete, Filter: byGroup}},
+		{name: "empty filter", request: model.BulkRequest{Operation: model.BulkDelete}, want: []string{"filter"}},
+		{
+			name:    "paged filter",
+			request: model.BulkRequest{Operation: model.BulkDelete, Filter: model.LibraryFilter{Group: "Muse", Page: 2}},
+			want:    []string{"filter"},
+		},
+		{name: "unknown operation", request: model.BulkRequest{Operation: "truncate", Filter: byGroup}, want: []string{"operation"}},
+		{
+			name:    "set field",
+			request: model.BulkRequest{Operation: model.BulkSetField, Filter: byGroup, Field: "releaseDate", Value: "01.01.2000"},
+		},
+		{
+			name:    "set unknown field",
+			request: model.BulkRequest{Operation: model.BulkSetField, Filter: byGroup, Field: "id", Value: "1"},
+			want:    []string{"field"},
+		},
+		{
+			name:    "set field without value",
+			request: model.BulkRequest{Operation: model.BulkSetField, Filter: byGroup, Field: "song"},
+			want:    []string{"value"},
+		},
+		{
+			name:    "set invalid link",
+			request: model.BulkRequest{Operation: model.BulkSetField, Filter: byGroup, Field: "link", Value: "example.com"},
+			want:    []string{"value"},
+		},
+		{
+			name:    "rename group",
+			request: model.BulkRequest{Operation: model.BulkRenameGroup, Filter: byGroup, NewGroup: "MUSE"},
+		},
+		{
+			name:    "rename without group filter",
+			request: model.BulkRequest{Operation: model.BulkRenameGroup, Filter: model.LibraryFilter{Song: "Uprising"}, NewGroup: "MUSE"},
+			want:    []string{"filter.group"},
+		},
+		{
+			name:    "rename without new name",
+			request: model.BulkRequest{Operation: model.BulkRenameGroup, Filter: byGroup},
+			want:    []string{"newGroup"},
+		},
+		{
+			name:    "invalid filter tag",
+			request: model.BulkRequest{Operation: model.BulkDelete, Filter: model.LibraryFilter{Tags: []string{"Rock"}}},
+			want:    []string{"filter.tags[0]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorFields(t, validateBulkRequest(tt.request)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateChangesRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   model.ChangesRequest
+		want      []string
+		wantSince model.ChangeCursor
+	}{
+		{name: "first sync", request: model.ChangesRequest{}},
+		{
+			name:      "with token",
+			request:   model.ChangesRequest{Since: encodeSyncToken(model.ChangeCursor{XID: 5, Seq: 9}), Limit: maxChangesLimit},
+			wantSince: model.ChangeCursor{XID: 5, Seq: 9},
+		},
+		{name: "bad token", request: model.ChangesRequest{Since: "garbage!"}, want: []string{"since"}},
+		{name: "negative limit", request: model.ChangesRequest{Limit: -1}, want: []string{"limit"}},
+		{name: "limit too large", request: model.ChangesRequest{Limit: maxChangesLimit + 1}, want: []string{"limit"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			since, err := validateChangesRequest(tt.request)
+			if got := errorFields(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+			if err == nil && since != tt.wantSince {
+				t.Errorf("since = %+v, want %+v", since, tt.wantSince)
+			}
+		})
+	}
+}
+
+func TestValidateSongRelationsRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		request model.SongRelationsRequest
+		want    []string
+	}{
+		{name: "defaults", request: model.SongRelationsRequest{SongID: 1}},
+		{
+			name:    "all filters",
+			request: model.SongRelationsRequest{SongID: 1, Type: model.RelationCoverOf, Direction: model.RelationIncoming, Depth: maxRelationDepth},
+		},
+		{name: "missing song", request: model.SongRelationsRequest{}, want: []string{"id"}},
+		{name: "unknown type", request: model.SongRelationsRequest{SongID: 1, Type: "sample_of"}, want: []string{"type"}},
+		{name: "unknown direction", request: model.SongRelationsRequest{SongID: 1, Direction: "both"}, want: []string{"direction"}},
+		{name: "negative depth", request: model.SongRelationsRequest{SongID: 1, Depth: -1}, want: []string{"depth"}},
+		{name: "depth too large", request: model.SongRelationsRequest{SongID: 1, Depth: maxRelationDepth + 1}, want: []string{"depth"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorFields(t, validateSongRelationsRequest(tt.request)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAddSongRelation(t *testing.T) {
+	tests := []struct {
+		name    string
+		request model.AddSongRelation
+		want    []string
+	}{
+		{name: "valid", request: model.AddSongRelation{SongID: 1, RelatedSongID: 2, Type: model.RelationCoverOf}},
+		{name: "self relation", request: model.AddSongRelation{SongID: 1, RelatedSongID: 1, Type: model.RelationCoverOf}, want: []string{"relatedSongId"}},
+		{name: "missing ids", request: model.AddSongRelation{Type: model.RelationCoverOf}, want: []string{"id", "relatedSongId"}},
+		{name: "missing type", request: model.AddSongRelation{SongID: 1, RelatedSongID: 2}, want: []string{"type"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorFields(t, validateAddSongRelation(tt.request)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAddAlbum(t *testing.T) {
+	tests := []struct {
+		name    string
+		request model.AddAlbum
+		want    []string
+	}{
+		{name: "valid", request: model.AddAlbum{Title: "Black Holes and Revelations", GroupID: 1, Tracks: []uint64{3, 1, 2}}},
+		{name: "missing title and group", request: model.AddAlbum{}, want: []string{"title", "groupId"}},
+		{name: "zero track", request: model.AddAlbum{Title: "A", GroupID: 1, Tracks: []uint64{1, 0}}, want: []string{"tracks[1]"}},
+		{name: "repeated track", request: model.AddAlbum{Title: "A", GroupID: 1, Tracks: []uint64{1, 2, 1}}, want: []string{"tracks[2]"}},
+		{name: "bad cover link", request: model.AddAlbum{Title: "A", GroupID: 1, CoverLink: "cover.jpg"}, want: []string{"coverLink"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorFields(t, validateAddAlbum(tt.request)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSetSongCredits(t *testing.T) {
+	tests := []struct {
+		name    string
+		request model.SetSongCredits
+		want    []string
+	}{
+		{name: "clearing credits", request: model.SetSongCredits{SongID: 1}},
+		{
+			name: "one person in several roles",
+			request: model.SetSongCredits{SongID: 1, Credits: []model.AddPersonCredit{
+				{Name: "Matt Bellamy", Role: model.PersonLyricist},
+				{Name: "Matt Bellamy", Role: model.PersonComposer},
+			}},
+		},
+		{
+			name: "repeated credit",
+			request: model.SetSongCredits{SongID: 1, Credits: []model.AddPersonCredit{
+				{Name: "Matt Bellamy", Role: model.PersonLyricist},
+				{Name: "Matt Bellamy", Role: model.PersonLyricist},
+			}},
+			want: []string{"credits[1].name"},
+		},
+		{
+			name:    "unknown role",
+			request: model.SetSongCredits{SongID: 1, Credits: []model.AddPersonCredit{{Name: "Rich Costey", Role: "engineer"}}},
+			want:    []string{"credits[0].role"},
+		},
+		{name: "missing song", request: model.SetSongCredits{}, want: []string{"id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorFields(t, validateSetSongCredits(tt.request)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1 @@
+drop table idempotency_keys;
\ No newline at end of file
@@ -0,0 +1,8 @@
+drop table song_tombstones;
+
+alter table songs
+    drop column change_seq,
+    drop column updated_at,
+    drop column created_at;
+
+drop sequence song_change_seq;
@@ -0,0 +1,16 @@
+CREATE SEQUENCE IF NOT EXISTS song_change_seq;
+
+ALTER TABLE songs
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN change_seq BIGINT NOT NULL DEFAULT nextval('song_change_seq');
+
+CREATE INDEX IF NOT EXISTS songs_change_seq_idx ON songs (change_seq);
+
+CREATE TABLE IF NOT EXISTS song_tombstones (
+    song_id BIGINT PRIMARY KEY,
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    change_seq BIGINT NOT NULL DEFAULT nextval('song_change_seq')
+);
+
+CREATE INDEX IF NOT EXISTS song_tombstones_change_seq_idx ON song_tombstones (change_seq);
@@ -0,0 +1,11 @@
+alter table songs add column group_name varchar(255);
+
+update songs set group_name = groups.name
+from groups
+where groups.id = songs.group_id;
+
+alter table songs
+    alter column group_name set not null,
+    drop column group_id;
+
+drop table groups;
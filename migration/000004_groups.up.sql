@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS groups (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    country VARCHAR(255) NOT NULL DEFAULT '',
+    formed_year INT,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO groups (name)
+SELECT DISTINCT group_name FROM songs
+ON CONFLICT (name) DO NOTHING;
+
+ALTER TABLE songs ADD COLUMN group_id BIGINT REFERENCES groups (id);
+
+UPDATE songs SET group_id = groups.id
+FROM groups
+WHERE groups.name = songs.group_name;
+
+ALTER TABLE songs
+    ALTER COLUMN group_id SET NOT NULL,
+    DROP COLUMN group_name;
+
+CREATE INDEX IF NOT EXISTS songs_group_id_idx ON songs (group_id);
@@ -0,0 +1,3 @@
+drop table album_tracks;
+
+drop table albums;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS albums (
+    id BIGSERIAL PRIMARY KEY,
+    group_id BIGINT NOT NULL REFERENCES groups (id),
+    title VARCHAR(255) NOT NULL,
+    release_date VARCHAR(255) NOT NULL DEFAULT '',
+    cover_link VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (group_id, title)
+);
+
+CREATE TABLE IF NOT EXISTS album_tracks (
+    album_id BIGINT NOT NULL REFERENCES albums (id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    song_id BIGINT NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    PRIMARY KEY (album_id, position),
+    UNIQUE (album_id, song_id)
+);
+
+CREATE INDEX IF NOT EXISTS album_tracks_song_id_idx ON album_tracks (song_id);
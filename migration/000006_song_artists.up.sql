@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS song_artists (
+    song_id BIGINT NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    group_id BIGINT NOT NULL REFERENCES groups (id),
+    role VARCHAR(32) NOT NULL CHECK (role IN ('primary', 'featured', 'remixer')),
+    position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (song_id, group_id)
+);
+
+CREATE INDEX IF NOT EXISTS song_artists_group_id_idx ON song_artists (group_id);
+
+INSERT INTO song_artists (song_id, group_id, role)
+SELECT id, group_id, 'primary' FROM songs
+ON CONFLICT (song_id, group_id) DO NOTHING;
@@ -0,0 +1,3 @@
+drop table song_people;
+
+drop table people;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS people (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS song_people (
+    song_id BIGINT NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    person_id BIGINT NOT NULL REFERENCES people (id),
+    role VARCHAR(32) NOT NULL CHECK (role IN ('lyricist', 'composer', 'producer', 'arranger')),
+    position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (song_id, person_id, role)
+);
+
+CREATE INDEX IF NOT EXISTS song_people_person_id_idx ON song_people (person_id, role);
@@ -0,0 +1,3 @@
+drop table song_tags;
+
+drop table tags;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS song_relations (
+    song_id BIGINT NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    related_song_id BIGINT NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    type VARCHAR(32) NOT NULL CHECK (type IN ('cover_of', 'remix_of', 'live_version_of', 'translation_of')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (song_id, related_song_id, type),
+    CHECK (song_id <> related_song_id)
+);
+
+CREATE INDEX IF NOT EXISTS song_relations_related_song_id_idx ON song_relations (related_song_id);
@@ -0,0 +1,3 @@
+alter table song_tombstones drop column change_xid;
+
+alter table songs drop column change_xid;
@@ -0,0 +1,11 @@
+-- change_xid records the transaction that wrote each change, so the change
+-- feed can stop below transactions that may still commit.
+ALTER TABLE songs
+    ADD COLUMN change_xid xid8 NOT NULL DEFAULT pg_current_xact_id();
+
+CREATE INDEX IF NOT EXISTS songs_change_xid_seq_idx ON songs (change_xid, change_seq);
+
+ALTER TABLE song_tombstones
+    ADD COLUMN change_xid xid8 NOT NULL DEFAULT pg_current_xact_id();
+
+CREATE INDEX IF NOT EXISTS song_tombstones_change_xid_seq_idx ON song_tombstones (change_xid, change_seq);
@@ -0,0 +1,40 @@
+// Package migration embeds the SQL migrations so binaries know which schema
+// version they were built against.
+package migration
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.sql
+var FS embed.FS
+
+// LatestVersion returns the highest migration version shipped with the binary.
+func LatestVersion() (uint, error) {
+	entries, err := fs.ReadDir(FS, ".")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint
+	for _, e := range entries {
+		prefix, _, found := strings.Cut(e.Name(), "_")
+		if !found {
+			continue
+		}
+
+		v, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("migration %s: %w", e.Name(), err)
+		}
+		if uint(v) > latest {
+			latest = uint(v)
+		}
+	}
+
+	return latest, nil
+}